@@ -0,0 +1,2 @@
+# Reads the user the provider is authenticated as
+data "litmus-chaos_current_user" "me" {}
@@ -0,0 +1,7 @@
+# Looks up the project configured as provider default project_id
+data "litmus-chaos_project" "default" {}
+
+# Looks up a specific project
+data "litmus-chaos_project" "main" {
+  project_id = "96fc3e7d-33ad-4891-8be2-ae4a30ba76cf"
+}
@@ -0,0 +1,6 @@
+# Reads the version and capabilities of the control plane
+data "litmus-chaos_server_info" "current" {}
+
+output "supports_environments" {
+  value = data.litmus-chaos_server_info.current.capabilities["environments"]
+}
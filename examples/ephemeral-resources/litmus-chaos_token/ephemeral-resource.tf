@@ -0,0 +1,10 @@
+# Issues a token for another provider without storing it in the plan or state
+ephemeral "litmus-chaos_token" "ci" {}
+
+provider "restapi" {
+  uri = "https://litmus-chaos.control.plane.url/api"
+
+  headers = {
+    Authorization = "Bearer ${ephemeral.litmus-chaos_token.ci.token}"
+  }
+}
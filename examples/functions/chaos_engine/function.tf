@@ -0,0 +1,37 @@
+# Run pod-delete against nginx, checking the frontend stays up
+resource "kubernetes_manifest" "nginx_chaos" {
+  manifest = yamldecode(provider::litmus-chaos::chaos_engine(
+    "nginx-chaos",
+    "default",
+    {
+      namespace = "default"
+      label     = "app=nginx"
+      kind      = "deployment"
+    },
+    "pod-delete",
+    {
+      TOTAL_CHAOS_DURATION = "30"
+      CHAOS_INTERVAL       = "10"
+    },
+    [
+      {
+        name = "check-frontend"
+        type = "httpProbe"
+        mode = "Continuous"
+        runProperties = {
+          probeTimeout = "5s"
+          interval     = "2s"
+        }
+        "httpProbe/inputs" = {
+          url = "http://nginx.default.svc"
+          method = {
+            get = {
+              criteria     = "=="
+              responseCode = "200"
+            }
+          }
+        }
+      },
+    ],
+  ))
+}
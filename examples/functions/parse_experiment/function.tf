@@ -0,0 +1,8 @@
+# Check every fault of an experiment exported from ChaosCenter is probed
+locals {
+  experiment = provider::litmus-chaos::parse_experiment(file("${path.module}/nginx-pod-delete.yaml"))
+}
+
+output "unprobed_faults" {
+  value = setsubtract(local.experiment.faults[*].name, local.experiment.probes[*].fault)
+}
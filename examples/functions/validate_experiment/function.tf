@@ -0,0 +1,16 @@
+# Fail the plan when an experiment manifest is invalid
+locals {
+  manifest = file("${path.module}/nginx-pod-delete.yaml")
+  problems = provider::litmus-chaos::validate_experiment(local.manifest)
+}
+
+resource "terraform_data" "nginx_pod_delete" {
+  input = local.manifest
+
+  lifecycle {
+    precondition {
+      condition     = length(local.problems) == 0
+      error_message = "Invalid experiment:\n${join("\n", local.problems)}"
+    }
+  }
+}
@@ -0,0 +1,2 @@
+# Environments are imported by project ID and environment ID, separated by a slash.
+terraform import litmus-chaos_environment.staging "96fc3e7d-33ad-4891-8be2-ae4a30ba76cf/staging"
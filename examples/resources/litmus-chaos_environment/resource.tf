@@ -0,0 +1,10 @@
+resource "litmus-chaos_project" "main_project" {
+  name = "Main Project"
+}
+
+resource "litmus-chaos_environment" "staging" {
+  project_id  = litmus-chaos_project.main_project.id
+  name        = "staging"
+  description = "Pre-production clusters"
+  type        = "NON_PROD"
+}
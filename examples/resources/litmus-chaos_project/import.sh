@@ -1,2 +1,5 @@
 # Project can be imported by specifying the uuid identifier.
-terraform import litmus_chaos_project.main_project "96fc3e7d-33ad-4891-8be2-ae4a30ba76cf"
+terraform import litmus-chaos_project.main_project "96fc3e7d-33ad-4891-8be2-ae4a30ba76cf"
+
+# Project can also be imported by name, as long as no other project has the same name.
+terraform import litmus-chaos_project.main_project "name:Main Project"
@@ -0,0 +1,15 @@
+# Rotate the default admin password right after installing the control plane
+resource "litmus-chaos_user_password" "admin" {
+  username     = "admin"
+  old_password = "litmus"
+  new_password = var.admin_password
+}
+
+# Reset the password of another user, the provider must be authenticated as an admin.
+# Passwords are write-only, so bump password_version to apply a new one.
+resource "litmus-chaos_user_password" "ci" {
+  username         = "ci-bot"
+  new_password     = var.ci_password
+  password_version = "2"
+  reset            = true
+}
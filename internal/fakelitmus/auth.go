@@ -0,0 +1,190 @@
+package fakelitmus
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// handleAuth serves the authentication server REST endpoints.
+func (s *Server) handleAuth(w http.ResponseWriter, r *http.Request) {
+	route := strings.TrimPrefix(r.URL.Path, "/auth")
+
+	switch {
+	case r.Method == http.MethodGet && route == "/status":
+		writeJSON(w, http.StatusOK, map[string]string{"status": "up", "version": s.version})
+	case r.Method == http.MethodGet && route == "/readiness":
+		writeJSON(w, http.StatusOK, map[string]string{"database": "up", "collections": "up"})
+	case r.Method == http.MethodPost && route == "/login":
+		s.login(w, r)
+	default:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		user := s.authenticate(r)
+		if user == nil {
+			writeError(w, http.StatusUnauthorized, "invalid or expired token")
+			return
+		}
+
+		s.handleAuthenticated(w, r, user, route)
+	}
+}
+
+// handleAuthenticated serves the endpoints requiring a token. Callers must
+// hold the lock.
+func (s *Server) handleAuthenticated(w http.ResponseWriter, r *http.Request, user *User, route string) {
+	switch {
+	case r.Method == http.MethodGet && route == "/users":
+		if user.Role != "admin" {
+			writeError(w, http.StatusForbidden, "only admins can list users")
+			return
+		}
+
+		users := make([]User, 0, len(s.users))
+		for _, u := range s.users {
+			users = append(users, *u)
+		}
+		writeJSON(w, http.StatusOK, users)
+	case r.Method == http.MethodGet && strings.HasPrefix(route, "/get_user/"):
+		for _, u := range s.users {
+			if u.ID == strings.TrimPrefix(route, "/get_user/") {
+				writeJSON(w, http.StatusOK, u)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "user not found")
+	case r.Method == http.MethodPost && route == "/update/password":
+		s.updatePassword(w, r, user)
+	case r.Method == http.MethodPost && route == "/reset/password":
+		s.resetPassword(w, r, user)
+	case r.Method == http.MethodPost && route == "/create_project":
+		var input struct {
+			ProjectName string `json:"projectName"`
+		}
+		if !decode(w, r, &input) {
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"data": s.createProject(input.ProjectName)})
+	case r.Method == http.MethodGet && route == "/list_projects":
+		projects := make([]*Project, 0, len(s.projects))
+		for _, project := range s.projects {
+			projects = append(projects, project)
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{
+			"projects":              projects,
+			"totalNumberOfProjects": len(projects),
+		}})
+	case r.Method == http.MethodGet && strings.HasPrefix(route, "/get_project/"):
+		project, ok := s.projects[strings.TrimPrefix(route, "/get_project/")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "project not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"data": project})
+	case r.Method == http.MethodPost && route == "/update_project_name":
+		var input struct {
+			ProjectID   string `json:"projectID"`
+			ProjectName string `json:"projectName"`
+		}
+		if !decode(w, r, &input) {
+			return
+		}
+
+		project, ok := s.projects[input.ProjectID]
+		if !ok {
+			writeError(w, http.StatusNotFound, "project not found")
+			return
+		}
+		project.Name = input.ProjectName
+		writeJSON(w, http.StatusOK, map[string]string{"message": "Successfully updated project name"})
+	default:
+		writeError(w, http.StatusNotFound, "no route for "+r.Method+" "+r.URL.Path)
+	}
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	if !decode(w, r, &input) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[input.Username]
+	if !ok || user.Password != input.Password {
+		writeError(w, http.StatusUnauthorized, "invalid credentials")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"accessToken": s.issueToken(user),
+		"expiresIn":   int(s.tokenTTL.Seconds()),
+		"tokenType":   "Bearer",
+	})
+}
+
+// updatePassword lets users change their own password. Callers must hold the
+// lock.
+func (s *Server) updatePassword(w http.ResponseWriter, r *http.Request, user *User) {
+	var input struct {
+		Username    string `json:"username"`
+		OldPassword string `json:"oldPassword"`
+		NewPassword string `json:"newPassword"`
+	}
+	if !decode(w, r, &input) {
+		return
+	}
+
+	if input.Username != user.Username {
+		writeError(w, http.StatusForbidden, "users can only change their own password")
+		return
+	}
+
+	if input.OldPassword != user.Password {
+		writeError(w, http.StatusUnauthorized, "invalid old password")
+		return
+	}
+
+	user.Password = input.NewPassword
+	writeJSON(w, http.StatusOK, map[string]string{"message": "password has been updated successfully"})
+}
+
+// resetPassword lets admins set the password of any user. Callers must hold
+// the lock.
+func (s *Server) resetPassword(w http.ResponseWriter, r *http.Request, user *User) {
+	var input struct {
+		Username    string `json:"username"`
+		NewPassword string `json:"newPassword"`
+	}
+	if !decode(w, r, &input) {
+		return
+	}
+
+	if user.Role != "admin" {
+		writeError(w, http.StatusForbidden, "only admins can reset passwords")
+		return
+	}
+
+	target, ok := s.users[input.Username]
+	if !ok {
+		writeError(w, http.StatusNotFound, "user not found")
+		return
+	}
+
+	target.Password = input.NewPassword
+	writeJSON(w, http.StatusOK, map[string]string{"message": "password has been reset successfully"})
+}
+
+func decode(w http.ResponseWriter, r *http.Request, out any) bool {
+	if err := json.NewDecoder(r.Body).Decode(out); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,69 @@
+package fakelitmus
+
+import "fmt"
+
+// noDocumentsMessage is the GraphQL error of 3.x servers for objects that do
+// not exist.
+const noDocumentsMessage = "mongo: no documents in result"
+
+// Environment is an environment of the fake control plane, served by 3.x
+// servers only.
+type Environment struct {
+	ProjectID     string `json:"projectID"`
+	EnvironmentID string `json:"environmentID"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Type          string `json:"type"`
+}
+
+// Environment returns the environment with the given ID in project.
+func (s *Server) Environment(projectID string, environmentID string) (Environment, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	environment, ok := s.environments[environmentKey(projectID, environmentID)]
+	if !ok {
+		return Environment{}, false
+	}
+
+	return *environment, true
+}
+
+// DeleteEnvironment removes an environment out of band.
+func (s *Server) DeleteEnvironment(projectID string, environmentID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.environments, environmentKey(projectID, environmentID))
+}
+
+// createEnvironment creates an environment from a CreateEnvironmentRequest.
+// Callers must hold the lock.
+func (s *Server) createEnvironment(projectID string, request map[string]any) (*Environment, error) {
+	if _, ok := s.projects[projectID]; !ok {
+		return nil, fmt.Errorf("project %s not found", projectID)
+	}
+
+	environment := &Environment{ProjectID: projectID}
+	environment.EnvironmentID, _ = request["environmentID"].(string)
+	environment.update(request)
+
+	key := environmentKey(projectID, environment.EnvironmentID)
+	if _, ok := s.environments[key]; ok {
+		return nil, fmt.Errorf("environment %s already exists", environment.EnvironmentID)
+	}
+	s.environments[key] = environment
+
+	return environment, nil
+}
+
+// update applies the fields of a create or update request.
+func (e *Environment) update(request map[string]any) {
+	e.Name, _ = request["name"].(string)
+	e.Description, _ = request["description"].(string)
+	e.Type, _ = request["type"].(string)
+}
+
+func environmentKey(projectID string, environmentID string) string {
+	return projectID + "/" + environmentID
+}
@@ -0,0 +1,156 @@
+package fakelitmus
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Fault is a scripted failure of an operation.
+type Fault struct {
+	// StatusCode makes the server answer with this HTTP status instead of
+	// handling the request.
+	StatusCode int
+
+	// GraphQLErrors makes the server answer with a 200 carrying these errors
+	// instead of handling the request.
+	GraphQLErrors []string
+
+	// Delay holds the response back, before failing or handling the request.
+	Delay time.Duration
+
+	// Times is how many requests the fault applies to, once when zero.
+	Times int
+}
+
+// InjectFault scripts a failure of operation for the next requests. REST
+// operations are named after their route without the IDs, e.g. "get_project"
+// or "update/password"; GraphQL operations after their field, e.g.
+// "getProject". Faults of an operation apply in the order they were injected.
+func (s *Server) InjectFault(operation string, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if fault.Times == 0 {
+		fault.Times = 1
+	}
+
+	s.faults[operation] = append(s.faults[operation], fault)
+}
+
+// Requests returns how many requests the server received for operation.
+func (s *Server) Requests(operation string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[operation]
+}
+
+// ExpireTokens expires every token issued so far, as happens when a long
+// apply outlives them.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for value, issued := range s.tokens {
+		issued.ExpiresAt = time.Now().Add(-time.Second)
+		s.tokens[value] = issued
+	}
+}
+
+// withFaults applies the scripted faults before handing requests to next.
+func (s *Server) withFaults(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		operation := operationOf(r)
+
+		s.mu.Lock()
+		s.requests[operation]++
+		fault, ok := s.nextFault(operation)
+		s.mu.Unlock()
+
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if fault.Delay > 0 {
+			select {
+			case <-time.After(fault.Delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		switch {
+		case fault.StatusCode != 0:
+			writeError(w, fault.StatusCode, "injected fault")
+		case len(fault.GraphQLErrors) > 0:
+			errs := make([]map[string]string, 0, len(fault.GraphQLErrors))
+			for _, message := range fault.GraphQLErrors {
+				errs = append(errs, map[string]string{"message": message})
+			}
+			writeJSON(w, http.StatusOK, map[string]any{"data": nil, "errors": errs})
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// nextFault consumes one occurrence of the first fault of operation. Callers
+// must hold the lock.
+func (s *Server) nextFault(operation string) (Fault, bool) {
+	faults := s.faults[operation]
+	if len(faults) == 0 {
+		return Fault{}, false
+	}
+
+	fault := faults[0]
+	faults[0].Times--
+	if faults[0].Times == 0 {
+		s.faults[operation] = faults[1:]
+	}
+
+	return fault, true
+}
+
+// idRoutes are the REST routes ending with an ID.
+var idRoutes = []string{"get_project", "get_user"}
+
+// operationOf names the operation of a request, see InjectFault.
+func operationOf(r *http.Request) string {
+	if route, ok := strings.CutPrefix(r.URL.Path, "/auth/"); ok {
+		for _, idRoute := range idRoutes {
+			if strings.HasPrefix(route, idRoute+"/") {
+				return idRoute
+			}
+		}
+		return route
+	}
+
+	if r.URL.Path != "/api/query" || r.Body == nil {
+		return r.URL.Path
+	}
+
+	// The body is read to find the GraphQL field and restored for the handler
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return r.URL.Path
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var req graphqlRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return r.URL.Path
+	}
+
+	for _, field := range graphqlFields {
+		if strings.Contains(req.Query, field) {
+			return field
+		}
+	}
+
+	return r.URL.Path
+}
@@ -0,0 +1,146 @@
+package fakelitmus
+
+import (
+	"net/http"
+	"strings"
+)
+
+// graphqlRequest is the body of a GraphQL request.
+type graphqlRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// graphqlFields are the fields of the GraphQL operations the fake serves.
+var graphqlFields = []string{
+	"__typename",
+	"getServerVersion",
+	"getVersionDetails",
+	"createProject",
+	"getProject",
+	"listProjects",
+	"updateProjectName",
+	"createEnvironment",
+	"getEnvironment",
+	"updateEnvironment",
+	"deleteEnvironment",
+}
+
+// handleGraphQL serves the GraphQL operations the provider uses. Operations
+// are recognized by the fields they select rather than by parsing the query,
+// which is enough for the fixed set of queries the provider sends.
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "GraphQL requests must be POSTs")
+		return
+	}
+
+	var req graphqlRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.authenticate(r) == nil {
+		writeError(w, http.StatusUnauthorized, "invalid or expired token")
+		return
+	}
+
+	data := map[string]any{}
+	var errs []string
+
+	v2 := strings.HasPrefix(s.version, "2.")
+	variable := func(name string) string {
+		value, _ := req.Variables[name].(string)
+		return value
+	}
+
+	switch {
+	case strings.Contains(req.Query, "__typename"):
+		data["__typename"] = "Query"
+	case strings.Contains(req.Query, "getServerVersion") && !v2:
+		data["getServerVersion"] = map[string]string{"key": "version", "value": s.version}
+	case strings.Contains(req.Query, "getVersionDetails") && v2:
+		data["getVersionDetails"] = map[string]string{"key": "version", "value": s.version}
+	case strings.Contains(req.Query, "createProject(") && v2:
+		data["createProject"] = v2Project(s.createProject(variable("projectName")))
+	case strings.Contains(req.Query, "getProject(") && v2:
+		project, ok := s.projects[variable("projectID")]
+		if !ok {
+			errs = append(errs, "project not found")
+			break
+		}
+		data["getProject"] = v2Project(project)
+	case strings.Contains(req.Query, "listProjects") && v2:
+		projects := make([]map[string]any, 0, len(s.projects))
+		for _, project := range s.projects {
+			projects = append(projects, v2Project(project))
+		}
+		data["listProjects"] = projects
+	case strings.Contains(req.Query, "updateProjectName(") && v2:
+		project, ok := s.projects[variable("projectID")]
+		if !ok {
+			errs = append(errs, "project not found")
+			break
+		}
+		project.Name = variable("projectName")
+		data["updateProjectName"] = "Successfully updated project name"
+	case strings.Contains(req.Query, "createEnvironment(") && !v2:
+		request, _ := req.Variables["request"].(map[string]any)
+		environment, err := s.createEnvironment(variable("projectID"), request)
+		if err != nil {
+			errs = append(errs, err.Error())
+			break
+		}
+		data["createEnvironment"] = environment
+	case strings.Contains(req.Query, "getEnvironment(") && !v2:
+		environment, ok := s.environments[environmentKey(variable("projectID"), variable("environmentID"))]
+		if !ok {
+			errs = append(errs, noDocumentsMessage)
+			break
+		}
+		data["getEnvironment"] = environment
+	case strings.Contains(req.Query, "updateEnvironment(") && !v2:
+		request, _ := req.Variables["request"].(map[string]any)
+		environmentID, _ := request["environmentID"].(string)
+		environment, ok := s.environments[environmentKey(variable("projectID"), environmentID)]
+		if !ok {
+			errs = append(errs, noDocumentsMessage)
+			break
+		}
+		environment.update(request)
+		data["updateEnvironment"] = "environment updated successfully"
+	case strings.Contains(req.Query, "deleteEnvironment(") && !v2:
+		key := environmentKey(variable("projectID"), variable("environmentID"))
+		if _, ok := s.environments[key]; !ok {
+			errs = append(errs, noDocumentsMessage)
+			break
+		}
+		delete(s.environments, key)
+		data["deleteEnvironment"] = "environment deleted successfully"
+	default:
+		errs = append(errs, "unsupported operation")
+	}
+
+	if len(errs) > 0 {
+		graphqlErrors := make([]map[string]string, 0, len(errs))
+		for _, message := range errs {
+			graphqlErrors = append(graphqlErrors, map[string]string{"message": message})
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"data": nil, "errors": graphqlErrors})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": data})
+}
+
+// v2Project is a project as returned by the 2.x GraphQL schema.
+func v2Project(project *Project) map[string]any {
+	return map[string]any{
+		"id":    project.ID,
+		"name":  project.Name,
+		"state": project.State,
+	}
+}
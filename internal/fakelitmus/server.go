@@ -0,0 +1,284 @@
+// Package fakelitmus implements an in-memory fake of the Litmus Chaos control
+// plane, serving the authentication server REST endpoints and the GraphQL
+// operations the provider uses. It runs in-process through httptest so that
+// provider tests do not need a real ChaosCenter.
+package fakelitmus
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// AdminUsername and AdminPassword are the credentials of the admin user
+	// every server starts with, as on a fresh ChaosCenter installation.
+	AdminUsername = "admin"
+	AdminPassword = "litmus"
+
+	// DefaultVersion is the version reported unless WithVersion is used.
+	DefaultVersion = "3.9.0"
+)
+
+// User is a user of the fake control plane.
+type User struct {
+	ID       string `json:"userID"`
+	Username string `json:"username"`
+	Password string `json:"-"`
+	Email    string `json:"email,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Role     string `json:"role"`
+}
+
+// Project is a project of the fake control plane.
+type Project struct {
+	ID    string `json:"projectID"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// Server is a running fake control plane. Its URL is the host to configure
+// the provider with; the authentication server is served under /auth and the
+// GraphQL server under /api/query.
+type Server struct {
+	*httptest.Server
+
+	version  string
+	tokenTTL time.Duration
+
+	mu           sync.Mutex
+	users        map[string]*User
+	projects     map[string]*Project
+	environments map[string]*Environment
+	tokens       map[string]token
+
+	faults   map[string][]Fault
+	requests map[string]int
+}
+
+// token is an access token issued by the fake.
+type token struct {
+	UserID    string
+	ExpiresAt time.Time
+}
+
+// Option customizes a Server.
+type Option func(*Server)
+
+// WithVersion makes the server report version, which selects the 2.x or 3.x
+// API in the provider.
+func WithVersion(version string) Option {
+	return func(s *Server) {
+		s.version = version
+	}
+}
+
+// WithTokenTTL sets the lifetime of the tokens issued on login.
+func WithTokenTTL(ttl time.Duration) Option {
+	return func(s *Server) {
+		s.tokenTTL = ttl
+	}
+}
+
+// NewServer starts a fake control plane with an admin user. It must be closed
+// once done.
+func NewServer(options ...Option) *Server {
+	s := &Server{
+		version:      DefaultVersion,
+		tokenTTL:     time.Hour,
+		users:        map[string]*User{},
+		projects:     map[string]*Project{},
+		environments: map[string]*Environment{},
+		tokens:       map[string]token{},
+		faults:       map[string][]Fault{},
+		requests:     map[string]int{},
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	s.AddUser(AdminUsername, AdminPassword, "admin")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/", s.handleAuth)
+	mux.HandleFunc("/api/query", s.handleGraphQL)
+	mux.HandleFunc("/api/readiness", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"database": "up"})
+	})
+
+	s.Server = httptest.NewServer(s.withFaults(mux))
+
+	return s
+}
+
+// Version returns the version the server reports.
+func (s *Server) Version() string {
+	return s.version
+}
+
+// AddUser creates a user and returns it.
+func (s *Server) AddUser(username string, password string, role string) User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user := &User{
+		ID:       newID(),
+		Username: username,
+		Password: password,
+		Role:     role,
+	}
+	s.users[username] = user
+
+	return *user
+}
+
+// User returns the user with the given username.
+func (s *Server) User(username string) (User, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[username]
+	if !ok {
+		return User{}, false
+	}
+
+	return *user, true
+}
+
+// AddProject creates a project out of band and returns it.
+func (s *Server) AddProject(name string) Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return *s.createProject(name)
+}
+
+// Project returns the project with the given ID.
+func (s *Server) Project(id string) (Project, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project, ok := s.projects[id]
+	if !ok {
+		return Project{}, false
+	}
+
+	return *project, true
+}
+
+// Projects returns every project.
+func (s *Server) Projects() []Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	projects := make([]Project, 0, len(s.projects))
+	for _, project := range s.projects {
+		projects = append(projects, *project)
+	}
+
+	return projects
+}
+
+// DeleteProject removes a project out of band.
+func (s *Server) DeleteProject(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.projects, id)
+}
+
+// Token issues a token for the admin user.
+func (s *Server) Token() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.issueToken(s.users[AdminUsername])
+}
+
+func (s *Server) createProject(name string) *Project {
+	project := &Project{
+		ID:    newID(),
+		Name:  name,
+		State: "active",
+	}
+	s.projects[project.ID] = project
+
+	return project
+}
+
+// issueToken returns a JWT shaped token for user, which the provider decodes
+// to know who it is authenticated as. Callers must hold the lock.
+func (s *Server) issueToken(user *User) string {
+	expiresAt := time.Now().Add(s.tokenTTL)
+
+	claims, _ := json.Marshal(map[string]any{
+		"uid":      user.ID,
+		"username": user.Username,
+		"role":     user.Role,
+		"exp":      expiresAt.Unix(),
+		"jti":      newID(),
+	})
+
+	value := "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+		base64.RawURLEncoding.EncodeToString(claims) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte("fakelitmus"))
+
+	s.tokens[value] = token{
+		UserID:    user.ID,
+		ExpiresAt: expiresAt,
+	}
+
+	return value
+}
+
+// authenticate returns the user the request is authenticated as, or nil when
+// the token is missing, unknown or expired. Callers must hold the lock.
+func (s *Server) authenticate(r *http.Request) *User {
+	value := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	issued, ok := s.tokens[value]
+	if !ok || time.Now().After(issued.ExpiresAt) {
+		return nil
+	}
+
+	for _, user := range s.users {
+		if user.ID == issued.UserID {
+			return user
+		}
+	}
+
+	return nil
+}
+
+// newID returns a random UUID shaped identifier.
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("fakelitmus: failed to generate id: %v", err))
+	}
+
+	id := hex.EncodeToString(b)
+
+	return id[0:8] + "-" + id[8:12] + "-" + id[12:16] + "-" + id[16:20] + "-" + id[20:32]
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{
+		"error":            http.StatusText(status),
+		"errorDescription": message,
+	})
+}
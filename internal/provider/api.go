@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/williamokano/litmus-chaos-thin-client/pkg/entities"
+	litmusgraphql "github.com/williamokano/litmus-chaos-thin-client/pkg/graphql"
+)
+
+// litmusAPI is the set of Litmus Chaos operations resources and data sources
+// rely on. Implementations hide the differences between server versions, so
+// resources are written once against this interface.
+type litmusAPI interface {
+	CreateProject(ctx context.Context, projectName string) (*entities.Project, error)
+	GetProject(ctx context.Context, projectID string) (*entities.Project, error)
+	ListProjects(ctx context.Context) ([]entities.Project, error)
+	UpdateProjectName(ctx context.Context, projectID string, projectName string) (*entities.Project, error)
+
+	CreateEnvironment(ctx context.Context, projectID string, request litmusgraphql.CreateEnvironmentRequest) (*entities.Environment, error)
+	GetEnvironment(ctx context.Context, projectID string, environmentID string) (*entities.Environment, error)
+	UpdateEnvironment(ctx context.Context, projectID string, request litmusgraphql.UpdateEnvironmentRequest) (*entities.Environment, error)
+	DeleteEnvironment(ctx context.Context, projectID string, environmentID string) error
+
+	FindUserByUsername(ctx context.Context, username string) (*entities.User, error)
+	UpdatePassword(ctx context.Context, username string, oldPassword string, newPassword string) error
+	ResetPassword(ctx context.Context, username string, newPassword string) error
+
+	CurrentUser(ctx context.Context) (*tokenClaims, error)
+	IssueToken(ctx context.Context) (string, error)
+	ServerInfo(ctx context.Context) (*serverInfo, error)
+}
+
+// errUnsupported is returned by the operations the server version lacks.
+// Resources check the server capabilities first, so that users get a
+// diagnostic naming the server version instead.
+var errUnsupported = errors.New("not supported by this Litmus Chaos server version")
+
+// Ensure the implementations satisfy the interface.
+var (
+	_ litmusAPI = &litmusV2API{}
+	_ litmusAPI = &litmusV3API{}
+)
+
+// newLitmusAPI returns a litmusAPI picking the implementation matching the
+// server version on first use, so that configuring the provider does not
+// contact the server.
+func newLitmusAPI(client *apiClient) *versionedAPI {
+	return &versionedAPI{client: client}
+}
+
+// versionedAPI delegates to the implementation matching the server version,
+// discovered by the first operation depending on it and cached afterwards.
+// Operations that do not depend on the version go to the client directly.
+type versionedAPI struct {
+	client *apiClient
+
+	mu  sync.Mutex
+	api litmusAPI
+}
+
+var _ litmusAPI = &versionedAPI{}
+
+// resolve returns the implementation matching the server version. Failing to
+// discover the version is an error rather than a guess, and is retried by the
+// next operation.
+func (v *versionedAPI) resolve(ctx context.Context) (litmusAPI, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.api != nil {
+		return v.api, nil
+	}
+
+	info, err := v.client.ServerInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover Litmus Chaos server version: %w", err)
+	}
+
+	tflog.Debug(ctx, "Discovered Litmus Chaos server version", map[string]any{"server_version": info.Version})
+
+	if strings.HasPrefix(strings.TrimPrefix(info.Version, "v"), "2.") {
+		v.api = &litmusV2API{apiClient: v.client}
+	} else {
+		v.api = &litmusV3API{apiClient: v.client}
+	}
+
+	return v.api, nil
+}
+
+func (v *versionedAPI) CreateProject(ctx context.Context, projectName string) (*entities.Project, error) {
+	api, err := v.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return api.CreateProject(ctx, projectName)
+}
+
+func (v *versionedAPI) GetProject(ctx context.Context, projectID string) (*entities.Project, error) {
+	api, err := v.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return api.GetProject(ctx, projectID)
+}
+
+func (v *versionedAPI) ListProjects(ctx context.Context) ([]entities.Project, error) {
+	api, err := v.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return api.ListProjects(ctx)
+}
+
+func (v *versionedAPI) UpdateProjectName(ctx context.Context, projectID string, projectName string) (*entities.Project, error) {
+	api, err := v.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return api.UpdateProjectName(ctx, projectID, projectName)
+}
+
+func (v *versionedAPI) CreateEnvironment(ctx context.Context, projectID string, request litmusgraphql.CreateEnvironmentRequest) (*entities.Environment, error) {
+	api, err := v.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return api.CreateEnvironment(ctx, projectID, request)
+}
+
+func (v *versionedAPI) GetEnvironment(ctx context.Context, projectID string, environmentID string) (*entities.Environment, error) {
+	api, err := v.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return api.GetEnvironment(ctx, projectID, environmentID)
+}
+
+func (v *versionedAPI) UpdateEnvironment(ctx context.Context, projectID string, request litmusgraphql.UpdateEnvironmentRequest) (*entities.Environment, error) {
+	api, err := v.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return api.UpdateEnvironment(ctx, projectID, request)
+}
+
+func (v *versionedAPI) DeleteEnvironment(ctx context.Context, projectID string, environmentID string) error {
+	api, err := v.resolve(ctx)
+	if err != nil {
+		return err
+	}
+	return api.DeleteEnvironment(ctx, projectID, environmentID)
+}
+
+func (v *versionedAPI) FindUserByUsername(ctx context.Context, username string) (*entities.User, error) {
+	return v.client.FindUserByUsername(ctx, username)
+}
+
+func (v *versionedAPI) UpdatePassword(ctx context.Context, username string, oldPassword string, newPassword string) error {
+	return v.client.UpdatePassword(ctx, username, oldPassword, newPassword)
+}
+
+func (v *versionedAPI) ResetPassword(ctx context.Context, username string, newPassword string) error {
+	return v.client.ResetPassword(ctx, username, newPassword)
+}
+
+func (v *versionedAPI) CurrentUser(ctx context.Context) (*tokenClaims, error) {
+	return v.client.CurrentUser(ctx)
+}
+
+func (v *versionedAPI) IssueToken(ctx context.Context) (string, error) {
+	return v.client.IssueToken(ctx)
+}
+
+func (v *versionedAPI) ServerInfo(ctx context.Context) (*serverInfo, error) {
+	return v.client.ServerInfo(ctx)
+}
@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/williamokano/terraform-provider-litmus-chaos/internal/fakelitmus"
+)
+
+func TestNewLitmusAPI(t *testing.T) {
+	testCases := map[string]struct {
+		serverVersion string
+		expected      string
+		expectedError string
+	}{
+		"2.x": {serverVersion: "2.14.0", expected: "*provider.litmusV2API"},
+		"3.x": {serverVersion: "3.9.1", expected: "*provider.litmusV3API"},
+		"unknown": {
+			expectedError: "failed to discover Litmus Chaos server version: failed to get server version: expected status_code 200, got 404",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// requests counts the GraphQL queries, those of the server version
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/query" {
+					requests++
+				}
+				if r.URL.Path != "/api/query" || testCase.serverVersion == "" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				fmt.Fprintf(w, `{"data":{"getServerVersion":{"key":"version","value":%q}}}`, testCase.serverVersion)
+			}))
+			defer server.Close()
+
+			api := newLitmusAPI(&apiClient{
+				authEndpoint: defaultAuthEndpoint(server.URL),
+				apiEndpoint:  defaultAPIEndpoint(server.URL),
+				tokens:       staticTokenSource("token"),
+				httpClient:   server.Client(),
+			})
+			if requests != 0 {
+				t.Fatalf("expected the server version to be discovered on first use, got %d requests", requests)
+			}
+
+			for range 2 {
+				resolved, err := api.resolve(context.Background())
+				if testCase.expectedError != "" {
+					if err == nil || !strings.HasPrefix(err.Error(), testCase.expectedError) {
+						t.Fatalf("expected error %q, got %v", testCase.expectedError, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if actual := fmt.Sprintf("%T", resolved); actual != testCase.expected {
+					t.Errorf("expected %s, got %s", testCase.expected, actual)
+				}
+			}
+
+			// Discovered versions are cached, failures are retried, each
+			// attempt also trying the 2.x query
+			expectedRequests := 1
+			if testCase.expectedError != "" {
+				expectedRequests = 4
+			}
+			if requests != expectedRequests {
+				t.Errorf("expected %d requests, got %d", expectedRequests, requests)
+			}
+		})
+	}
+}
+
+func TestConfigureDoesNotDiscoverServerVersion(t *testing.T) {
+	server := fakelitmus.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	resp := testConfigureProvider(ctx, t, map[string]tftypes.Value{
+		"host":                  tftypes.NewValue(tftypes.String, server.URL),
+		"token":                 tftypes.NewValue(tftypes.String, server.Token()),
+		"validate_on_configure": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if requests := server.Requests("getServerVersion"); requests != 0 {
+		t.Errorf("expected no server version request when configuring, got %d", requests)
+	}
+
+	api := resp.ResourceData.(*providerData).client //nolint:forcetypeassert // checked by Configure
+	if _, err := api.ListProjects(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := api.ListProjects(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if requests := server.Requests("getServerVersion"); requests != 1 {
+		t.Errorf("expected the server version to be requested once, got %d", requests)
+	}
+}
+
+func TestLitmusAPIAgainstFakeServer(t *testing.T) {
+	for _, serverVersion := range []string{"2.14.0", "3.9.0"} {
+		t.Run(serverVersion, func(t *testing.T) {
+			server := fakelitmus.NewServer(fakelitmus.WithVersion(serverVersion))
+			defer server.Close()
+
+			ctx := context.Background()
+			resp := testConfigureProvider(ctx, t, map[string]tftypes.Value{
+				"host":     tftypes.NewValue(tftypes.String, server.URL),
+				"username": tftypes.NewValue(tftypes.String, fakelitmus.AdminUsername),
+				"password": tftypes.NewValue(tftypes.String, fakelitmus.AdminPassword),
+			})
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			api := resp.ResourceData.(*providerData).client //nolint:forcetypeassert // checked by Configure
+
+			project, err := api.CreateProject(ctx, "tf-acc-project")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			project, err = api.UpdateProjectName(ctx, project.ID, "tf-acc-renamed")
+			if err != nil || project.Name != "tf-acc-renamed" {
+				t.Fatalf("expected renamed project, got %+v (%v)", project, err)
+			}
+
+			if actual, ok := server.Project(project.ID); !ok || actual.Name != "tf-acc-renamed" {
+				t.Errorf("expected the server to hold the renamed project, got %+v", actual)
+			}
+
+			projects, err := api.ListProjects(ctx)
+			if err != nil || len(projects) != 1 || projects[0].ID != project.ID {
+				t.Errorf("expected the project to be listed, got %+v (%v)", projects, err)
+			}
+
+			user, err := api.FindUserByUsername(ctx, fakelitmus.AdminUsername)
+			if err != nil || user.Role != "admin" {
+				t.Errorf("expected the admin user, got %+v (%v)", user, err)
+			}
+
+			info, err := api.ServerInfo(ctx)
+			if err != nil || info.Version != serverVersion || !info.Ready {
+				t.Errorf("unexpected server info %+v (%v)", info, err)
+			}
+		})
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	testCases := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"404": {
+			err:      fmt.Errorf("failed to get project: %w", &statusError{StatusCode: http.StatusNotFound}),
+			expected: true,
+		},
+		"403": {
+			err: &statusError{StatusCode: http.StatusForbidden},
+		},
+		"missing document": {
+			err:      &graphqlError{Messages: []string{"mongo: no documents in result"}},
+			expected: true,
+		},
+		"missing project": {
+			err:      &graphqlError{Messages: []string{"permission denied", "project not found"}},
+			expected: true,
+		},
+		"missing user": {
+			err: &graphqlError{Messages: []string{"user not found"}},
+		},
+		"missing environment": {
+			err: &graphqlError{Messages: []string{"environment not found in project"}},
+		},
+		"other error": {
+			err: fmt.Errorf("connection refused"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if actual := isNotFound(testCase.err); actual != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/williamokano/litmus-chaos-thin-client/pkg/entities"
+	litmusgraphql "github.com/williamokano/litmus-chaos-thin-client/pkg/graphql"
+)
+
+// litmusV2API talks to Litmus Chaos 2.x, where projects are managed by the
+// GraphQL server instead of the authentication server.
+type litmusV2API struct {
+	*apiClient
+}
+
+// v2Project is the project type of the 2.x GraphQL schema.
+type v2Project struct {
+	ID    string  `json:"id"`
+	Name  string  `json:"name"`
+	State *string `json:"state"`
+}
+
+func (p v2Project) entity() *entities.Project {
+	return &entities.Project{
+		ID:    p.ID,
+		Name:  p.Name,
+		State: p.State,
+	}
+}
+
+func (a *litmusV2API) CreateProject(ctx context.Context, projectName string) (*entities.Project, error) {
+	var res struct {
+		CreateProject v2Project `json:"createProject"`
+	}
+	err := a.graphql(ctx, `mutation createProject($projectName: String!) {
+  createProject(projectName: $projectName) { id name state }
+}`, map[string]any{"projectName": projectName}, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project with name %s: %w", projectName, err)
+	}
+
+	return res.CreateProject.entity(), nil
+}
+
+func (a *litmusV2API) GetProject(ctx context.Context, projectID string) (*entities.Project, error) {
+	var res struct {
+		GetProject v2Project `json:"getProject"`
+	}
+	err := a.graphql(ctx, `query getProject($projectID: String!) {
+  getProject(projectID: $projectID) { id name state }
+}`, map[string]any{"projectID": projectID}, &res)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("failed to get project by id: %w: %w", errNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get project by id: %w", err)
+	}
+
+	return res.GetProject.entity(), nil
+}
+
+// ListProjects lists the projects the user is a member of, every project for
+// admins.
+func (a *litmusV2API) ListProjects(ctx context.Context) ([]entities.Project, error) {
+	var res struct {
+		ListProjects []v2Project `json:"listProjects"`
+	}
+	err := a.graphql(ctx, `query listProjects {
+  listProjects { id name state }
+}`, nil, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	projects := make([]entities.Project, 0, len(res.ListProjects))
+	for _, project := range res.ListProjects {
+		projects = append(projects, *project.entity())
+	}
+
+	return projects, nil
+}
+
+func (a *litmusV2API) UpdateProjectName(ctx context.Context, projectID string, projectName string) (*entities.Project, error) {
+	err := a.graphql(ctx, `mutation updateProjectName($projectID: String!, $projectName: String!) {
+  updateProjectName(projectID: $projectID, projectName: $projectName)
+}`, map[string]any{"projectID": projectID, "projectName": projectName}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update project name: %w", err)
+	}
+
+	return a.GetProject(ctx, projectID)
+}
+
+// CreateEnvironment fails, as do the other environment operations, since
+// environments were introduced by Litmus Chaos 3.0.
+func (a *litmusV2API) CreateEnvironment(context.Context, string, litmusgraphql.CreateEnvironmentRequest) (*entities.Environment, error) {
+	return nil, fmt.Errorf("environments are %w", errUnsupported)
+}
+
+func (a *litmusV2API) GetEnvironment(context.Context, string, string) (*entities.Environment, error) {
+	return nil, fmt.Errorf("environments are %w", errUnsupported)
+}
+
+func (a *litmusV2API) UpdateEnvironment(context.Context, string, litmusgraphql.UpdateEnvironmentRequest) (*entities.Environment, error) {
+	return nil, fmt.Errorf("environments are %w", errUnsupported)
+}
+
+func (a *litmusV2API) DeleteEnvironment(context.Context, string, string) error {
+	return fmt.Errorf("environments are %w", errUnsupported)
+}
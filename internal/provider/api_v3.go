@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/williamokano/litmus-chaos-thin-client/pkg/entities"
+	litmusgraphql "github.com/williamokano/litmus-chaos-thin-client/pkg/graphql"
+)
+
+// litmusV3API talks to Litmus Chaos 3.x, where projects and users are both
+// managed by the authentication server.
+type litmusV3API struct {
+	*apiClient
+}
+
+func (a *litmusV3API) CreateProject(ctx context.Context, projectName string) (*entities.Project, error) {
+	var res authResponse[entities.Project]
+	err := a.auth(ctx, http.MethodPost, "/create_project", entities.CreateProjectInput{
+		ProjectName: projectName,
+	}, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project with name %s: %w", projectName, err)
+	}
+
+	return &res.Data, nil
+}
+
+func (a *litmusV3API) GetProject(ctx context.Context, projectID string) (*entities.Project, error) {
+	var res authResponse[entities.Project]
+	err := a.auth(ctx, http.MethodGet, "/get_project/"+projectID, nil, &res)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("failed to get project by id: %w: %w", errNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get project by id: %w", err)
+	}
+
+	return &res.Data, nil
+}
+
+// ListProjects lists the projects the user is a member of, every project for
+// admins.
+func (a *litmusV3API) ListProjects(ctx context.Context) ([]entities.Project, error) {
+	var res authResponse[struct {
+		Projects []entities.Project `json:"projects"`
+	}]
+	err := a.auth(ctx, http.MethodGet, "/list_projects", nil, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	return res.Data.Projects, nil
+}
+
+func (a *litmusV3API) UpdateProjectName(ctx context.Context, projectID string, projectName string) (*entities.Project, error) {
+	err := a.auth(ctx, http.MethodPost, "/update_project_name", entities.UpdateProjectNameInput{
+		ProjectID:   projectID,
+		ProjectName: projectName,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update project name: %w", err)
+	}
+
+	return a.GetProject(ctx, projectID)
+}
+
+// environmentFields are the fields of the environments the provider reads.
+const environmentFields = `projectID environmentID name description type`
+
+// CreateEnvironment creates an environment in the project. Unless the request
+// sets one, the environment ID is derived from its name as the ChaosCenter UI
+// does.
+func (a *litmusV3API) CreateEnvironment(ctx context.Context, projectID string, request litmusgraphql.CreateEnvironmentRequest) (*entities.Environment, error) {
+	if request.EnvironmentID == "" {
+		request.EnvironmentID = request.IDFromName()
+	}
+
+	var res struct {
+		CreateEnvironment entities.Environment `json:"createEnvironment"`
+	}
+	err := a.graphql(ctx, `mutation createEnvironment($projectID: ID!, $request: CreateEnvironmentRequest!) {
+  createEnvironment(projectID: $projectID, request: $request) { `+environmentFields+` }
+}`, map[string]any{"projectID": projectID, "request": request}, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create environment with name %s: %w", request.Name, err)
+	}
+
+	return &res.CreateEnvironment, nil
+}
+
+func (a *litmusV3API) GetEnvironment(ctx context.Context, projectID string, environmentID string) (*entities.Environment, error) {
+	var res struct {
+		GetEnvironment entities.Environment `json:"getEnvironment"`
+	}
+	err := a.graphql(ctx, `query getEnvironment($projectID: ID!, $environmentID: ID!) {
+  getEnvironment(projectID: $projectID, environmentID: $environmentID) { `+environmentFields+` }
+}`, map[string]any{"projectID": projectID, "environmentID": environmentID}, &res)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("failed to get environment by id: %w: %w", errNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get environment by id: %w", err)
+	}
+
+	return &res.GetEnvironment, nil
+}
+
+func (a *litmusV3API) UpdateEnvironment(ctx context.Context, projectID string, request litmusgraphql.UpdateEnvironmentRequest) (*entities.Environment, error) {
+	err := a.graphql(ctx, `mutation updateEnvironment($projectID: ID!, $request: UpdateEnvironmentRequest!) {
+  updateEnvironment(projectID: $projectID, request: $request)
+}`, map[string]any{"projectID": projectID, "request": request}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update environment: %w", err)
+	}
+
+	return a.GetEnvironment(ctx, projectID, request.EnvironmentID)
+}
+
+func (a *litmusV3API) DeleteEnvironment(ctx context.Context, projectID string, environmentID string) error {
+	err := a.graphql(ctx, `mutation deleteEnvironment($projectID: ID!, $environmentID: ID!) {
+  deleteEnvironment(projectID: $projectID, environmentID: $environmentID)
+}`, map[string]any{"projectID": projectID, "environmentID": environmentID}, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("failed to delete environment: %w: %w", errNotFound, err)
+		}
+		return fmt.Errorf("failed to delete environment: %w", err)
+	}
+
+	return nil
+}
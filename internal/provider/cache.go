@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/williamokano/litmus-chaos-thin-client/pkg/entities"
+)
+
+// cachingAPI caches the lookups of a litmusAPI for the lifetime of the
+// provider, that is a single Terraform command. Many data sources of a
+// configuration may look up the same objects, and some lookups are costly,
+// e.g. finding a user lists every user of the control plane.
+type cachingAPI struct {
+	litmusAPI
+
+	users lookupCache[*entities.User]
+}
+
+func newCachingAPI(api litmusAPI) *cachingAPI {
+	return &cachingAPI{litmusAPI: api}
+}
+
+// FindUserByUsername looks up a user by its username, once per username.
+func (c *cachingAPI) FindUserByUsername(ctx context.Context, username string) (*entities.User, error) {
+	return c.users.get(username, func() (*entities.User, error) {
+		return c.litmusAPI.FindUserByUsername(ctx, username)
+	})
+}
+
+// lookupCache memoizes successful lookups by key. Concurrent lookups of the
+// same key wait for the first one instead of hitting the server again.
+type lookupCache[V any] struct {
+	mu      sync.Mutex
+	entries map[string]*lookupEntry[V]
+}
+
+type lookupEntry[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// get returns the cached value of key, calling lookup to get it when it is
+// not cached. Failed lookups are not cached.
+func (c *lookupCache[V]) get(key string, lookup func() (V, error)) (V, error) {
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = map[string]*lookupEntry[V]{}
+	}
+
+	if entry, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		<-entry.done
+
+		if entry.err == nil {
+			return entry.value, nil
+		}
+
+		// The lookup we waited for failed, try again
+		return c.get(key, lookup)
+	}
+
+	entry := &lookupEntry[V]{done: make(chan struct{})}
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	entry.value, entry.err = lookup()
+	if entry.err != nil {
+		c.mu.Lock()
+		delete(c.entries, key)
+		c.mu.Unlock()
+	}
+	close(entry.done)
+
+	return entry.value, entry.err
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/williamokano/litmus-chaos-thin-client/pkg/entities"
+)
+
+func TestCachingAPIFindUserByUsername(t *testing.T) {
+	var lookups atomic.Int32
+	failing := true
+
+	api := newCachingAPI(&mockAPI{
+		findUserByUsername: func(_ context.Context, username string) (*entities.User, error) {
+			lookups.Add(1)
+			if username == "flaky" && failing {
+				failing = false
+				return nil, errors.New("connection refused")
+			}
+			return &entities.User{ID: username + "-id", Username: username}, nil
+		},
+	})
+
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if user, err := api.FindUserByUsername(ctx, "admin"); err != nil || user.ID != "admin-id" {
+				t.Errorf("expected the admin user, got %+v (%v)", user, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if actual := lookups.Load(); actual != 1 {
+		t.Errorf("expected a single lookup, got %d", actual)
+	}
+
+	if _, err := api.FindUserByUsername(ctx, "flaky"); err == nil {
+		t.Fatal("expected the first lookup to fail")
+	}
+	if _, err := api.FindUserByUsername(ctx, "flaky"); err != nil {
+		t.Errorf("expected failed lookups not to be cached, got: %v", err)
+	}
+
+	if actual := lookups.Load(); actual != 3 {
+		t.Errorf("expected 3 lookups, got %d", actual)
+	}
+}
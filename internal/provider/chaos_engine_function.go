@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+var _ function.Function = &chaosEngineFunction{}
+
+// chaosEngineFunction renders ChaosEngine manifests, for teams applying them
+// with the Kubernetes provider rather than through the control plane.
+type chaosEngineFunction struct{}
+
+func NewChaosEngineFunction() function.Function {
+	return &chaosEngineFunction{}
+}
+
+type chaosEngineApp struct {
+	Namespace string `tfsdk:"namespace"`
+	Label     string `tfsdk:"label"`
+	Kind      string `tfsdk:"kind"`
+}
+
+// chaosEngine is a ChaosEngine manifest, its fields ordered as in the Litmus
+// Chaos documentation.
+type chaosEngine struct {
+	APIVersion string          `yaml:"apiVersion"`
+	Kind       string          `yaml:"kind"`
+	Metadata   chaosEngineMeta `yaml:"metadata"`
+	Spec       chaosEngineSpec `yaml:"spec"`
+}
+
+type chaosEngineMeta struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace"`
+}
+
+type chaosEngineSpec struct {
+	EngineState         string                  `yaml:"engineState"`
+	AppInfo             chaosEngineAppInfo      `yaml:"appinfo"`
+	ChaosServiceAccount string                  `yaml:"chaosServiceAccount"`
+	Experiments         []chaosEngineExperiment `yaml:"experiments"`
+}
+
+type chaosEngineAppInfo struct {
+	AppNS    string `yaml:"appns"`
+	AppLabel string `yaml:"applabel"`
+	AppKind  string `yaml:"appkind"`
+}
+
+type chaosEngineExperiment struct {
+	Name string                    `yaml:"name"`
+	Spec chaosEngineExperimentSpec `yaml:"spec"`
+}
+
+type chaosEngineExperimentSpec struct {
+	Components chaosEngineComponents `yaml:"components"`
+	Probe      []chaosEngineProbe    `yaml:"probe,omitempty"`
+}
+
+// chaosEngineProbe is a resilience probe, its type specific "<type>/inputs"
+// and any other field kept in Fields.
+type chaosEngineProbe struct {
+	Name          string         `yaml:"name"`
+	Type          string         `yaml:"type"`
+	Mode          string         `yaml:"mode"`
+	RunProperties any            `yaml:"runProperties"`
+	Fields        map[string]any `yaml:",inline"`
+}
+
+// newChaosEngineProbe orders the fields of a validated probe.
+func newChaosEngineProbe(probe map[string]any) chaosEngineProbe {
+	fields := make(map[string]any, len(probe))
+	for key, value := range probe {
+		fields[key] = value
+	}
+
+	ordered := chaosEngineProbe{Fields: fields}
+	ordered.Name, _ = fields["name"].(string)
+	ordered.Type, _ = fields["type"].(string)
+	ordered.Mode, _ = fields["mode"].(string)
+	ordered.RunProperties = fields["runProperties"]
+	for _, key := range []string{"name", "type", "mode", "runProperties"} {
+		delete(fields, key)
+	}
+
+	return ordered
+}
+
+type chaosEngineComponents struct {
+	Env []chaosEngineEnv `yaml:"env,omitempty"`
+}
+
+type chaosEngineEnv struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+func (f *chaosEngineFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "chaos_engine"
+}
+
+func (f *chaosEngineFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Renders a ChaosEngine manifest",
+		Description: "Renders the YAML manifest of a ChaosEngine running a single fault against an application, " +
+			"validated offline without contacting the control plane. The engine runs with the `<fault>-sa` service account.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "Name of the ChaosEngine.",
+			},
+			function.StringParameter{
+				Name:        "namespace",
+				Description: "Namespace of the ChaosEngine, where the fault runs.",
+			},
+			function.ObjectParameter{
+				Name: "app",
+				Description: "Application under chaos: its `namespace`, `label` selector such as `app=nginx`, " +
+					"and `kind`, one of " + strings.Join(appKinds, ", ") + ".",
+				AttributeTypes: map[string]attr.Type{
+					"namespace": types.StringType,
+					"label":     types.StringType,
+					"kind":      types.StringType,
+				},
+			},
+			function.StringParameter{
+				Name:        "fault",
+				Description: "Name of the fault to run, such as `pod-delete`.",
+			},
+			function.MapParameter{
+				Name:           "env",
+				Description:    "Environment variables overriding the defaults of the fault, such as `TOTAL_CHAOS_DURATION`.",
+				ElementType:    types.StringType,
+				AllowNullValue: true,
+			},
+			function.DynamicParameter{
+				Name: "probes",
+				Description: "Resilience probes, as a list of objects with the `name`, `type`, `mode`, `runProperties` " +
+					"and `<type>/inputs` fields of Litmus Chaos probes.",
+				AllowNullValue: true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *chaosEngineFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var (
+		name      string
+		namespace string
+		app       chaosEngineApp
+		fault     string
+		env       map[string]string
+		probes    types.Dynamic
+	)
+
+	resp.Error = req.Arguments.Get(ctx, &name, &namespace, &app, &fault, &env, &probes)
+	if resp.Error != nil {
+		return
+	}
+
+	var problems []string
+	problems = append(problems, validateDNSSubdomain("name", name)...)
+	problems = append(problems, validateDNSLabel("namespace", namespace)...)
+	problems = append(problems, validateDNSLabel("app.namespace", app.Namespace)...)
+	if !strings.Contains(app.Label, "=") {
+		problems = append(problems, fmt.Sprintf("app.label: %q must be a label selector such as app=nginx", app.Label))
+	}
+	problems = append(problems, validateOneOf("app.kind", app.Kind, appKinds)...)
+	problems = append(problems, validateDNSLabel("fault", fault)...)
+	problems = append(problems, validateEnvNames("env", env)...)
+
+	probeList, err := chaosEngineProbes(probes)
+	if err != nil {
+		problems = append(problems, "probes: "+err.Error())
+	}
+	problems = append(problems, validateProbes("probes", probeList)...)
+
+	if len(problems) > 0 {
+		resp.Error = function.NewFuncError("Invalid ChaosEngine:\n  - " + strings.Join(problems, "\n  - "))
+		return
+	}
+
+	experiment := chaosEngineExperiment{Name: fault}
+	for _, envName := range sortedKeys(env) {
+		experiment.Spec.Components.Env = append(experiment.Spec.Components.Env, chaosEngineEnv{
+			Name:  envName,
+			Value: env[envName],
+		})
+	}
+	for _, probe := range probeList {
+		experiment.Spec.Probe = append(experiment.Spec.Probe, newChaosEngineProbe(probe.(map[string]any))) //nolint:forcetypeassert // validated
+	}
+
+	manifest, err := marshalManifest(chaosEngine{
+		APIVersion: "litmuschaos.io/v1alpha1",
+		Kind:       "ChaosEngine",
+		Metadata: chaosEngineMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: chaosEngineSpec{
+			EngineState: "active",
+			AppInfo: chaosEngineAppInfo{
+				AppNS:    app.Namespace,
+				AppLabel: app.Label,
+				AppKind:  app.Kind,
+			},
+			ChaosServiceAccount: fault + "-sa",
+			Experiments:         []chaosEngineExperiment{experiment},
+		},
+	})
+	if err != nil {
+		resp.Error = function.NewFuncError("Failed to render ChaosEngine: " + err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, string(manifest))
+}
+
+// chaosEngineProbes converts the probes argument, null meaning no probes.
+func chaosEngineProbes(probes types.Dynamic) ([]any, error) {
+	value, err := goValue(probes)
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	list, ok := value.([]any)
+	if !ok {
+		return nil, errors.New("must be a list of probes")
+	}
+
+	return list, nil
+}
+
+// marshalManifest renders a Kubernetes manifest with the usual two spaces
+// indentation.
+func marshalManifest(manifest any) ([]byte, error) {
+	var b bytes.Buffer
+
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(manifest); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// testChaosEngineArguments returns valid chaos_engine arguments, with probes.
+func testChaosEngineArguments(probes types.Dynamic) []attr.Value {
+	return []attr.Value{
+		types.StringValue("nginx-chaos"),
+		types.StringValue("litmus"),
+		types.ObjectValueMust(
+			map[string]attr.Type{"namespace": types.StringType, "label": types.StringType, "kind": types.StringType},
+			map[string]attr.Value{
+				"namespace": types.StringValue("default"),
+				"label":     types.StringValue("app=nginx"),
+				"kind":      types.StringValue("deployment"),
+			},
+		),
+		types.StringValue("pod-delete"),
+		types.MapValueMust(types.StringType, map[string]attr.Value{
+			"TOTAL_CHAOS_DURATION": types.StringValue("30"),
+			"CHAOS_INTERVAL":       types.StringValue("10"),
+		}),
+		probes,
+	}
+}
+
+func testHTTPProbe(name string, mode string) attr.Value {
+	runProperties := types.ObjectValueMust(
+		map[string]attr.Type{"probeTimeout": types.StringType, "interval": types.StringType, "retry": types.NumberType},
+		map[string]attr.Value{
+			"probeTimeout": types.StringValue("5s"),
+			"interval":     types.StringValue("2s"),
+			"retry":        types.NumberValue(big.NewFloat(1)),
+		},
+	)
+	inputs := types.ObjectValueMust(
+		map[string]attr.Type{"url": types.StringType},
+		map[string]attr.Value{"url": types.StringValue("http://nginx.default.svc")},
+	)
+
+	return types.ObjectValueMust(
+		map[string]attr.Type{
+			"name":             types.StringType,
+			"type":             types.StringType,
+			"mode":             types.StringType,
+			"runProperties":    runProperties.Type(context.Background()),
+			"httpProbe/inputs": inputs.Type(context.Background()),
+		},
+		map[string]attr.Value{
+			"name":             types.StringValue(name),
+			"type":             types.StringValue("httpProbe"),
+			"mode":             types.StringValue(mode),
+			"runProperties":    runProperties,
+			"httpProbe/inputs": inputs,
+		},
+	)
+}
+
+func testRunFunction(f function.Function, arguments []attr.Value) (string, *function.FuncError) {
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(arguments)}, resp)
+
+	result, _ := resp.Result.Value().(types.String)
+
+	return result.ValueString(), resp.Error
+}
+
+func TestChaosEngineFunction(t *testing.T) {
+	probe := testHTTPProbe("check-frontend", "Continuous")
+	probes := types.DynamicValue(types.TupleValueMust([]attr.Type{probe.Type(context.Background())}, []attr.Value{probe}))
+
+	manifest, err := testRunFunction(NewChaosEngineFunction(), testChaosEngineArguments(probes))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `apiVersion: litmuschaos.io/v1alpha1
+kind: ChaosEngine
+metadata:
+  name: nginx-chaos
+  namespace: litmus
+spec:
+  engineState: active
+  appinfo:
+    appns: default
+    applabel: app=nginx
+    appkind: deployment
+  chaosServiceAccount: pod-delete-sa
+  experiments:
+    - name: pod-delete
+      spec:
+        components:
+          env:
+            - name: CHAOS_INTERVAL
+              value: "10"
+            - name: TOTAL_CHAOS_DURATION
+              value: "30"
+        probe:
+          - name: check-frontend
+            type: httpProbe
+            mode: Continuous
+            runProperties:
+              interval: 2s
+              probeTimeout: 5s
+              retry: 1
+            httpProbe/inputs:
+              url: http://nginx.default.svc
+`
+	if manifest != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, manifest)
+	}
+
+	t.Run("without probes", func(t *testing.T) {
+		manifest, err := testRunFunction(NewChaosEngineFunction(), testChaosEngineArguments(types.DynamicNull()))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.Contains(manifest, "probe:") {
+			t.Errorf("expected no probes, got:\n%s", manifest)
+		}
+	})
+}
+
+func TestChaosEngineFunctionErrors(t *testing.T) {
+	testCases := map[string]struct {
+		update   func(arguments []attr.Value)
+		expected string
+	}{
+		"invalid name": {
+			update:   func(arguments []attr.Value) { arguments[0] = types.StringValue("Nginx_Chaos") },
+			expected: `name: "Nginx_Chaos" must consist of lower case alphanumeric characters`,
+		},
+		"invalid app": {
+			update: func(arguments []attr.Value) {
+				arguments[2] = types.ObjectValueMust(
+					map[string]attr.Type{"namespace": types.StringType, "label": types.StringType, "kind": types.StringType},
+					map[string]attr.Value{
+						"namespace": types.StringValue("default"),
+						"label":     types.StringValue("nginx"),
+						"kind":      types.StringValue("pod"),
+					},
+				)
+			},
+			expected: "  - app.label: \"nginx\" must be a label selector such as app=nginx\n" +
+				"  - app.kind: \"pod\" must be one of deployment, statefulset, daemonset, deploymentconfig, rollout",
+		},
+		"invalid env": {
+			update: func(arguments []attr.Value) {
+				arguments[4] = types.MapValueMust(types.StringType, map[string]attr.Value{"TOTAL CHAOS": types.StringValue("30")})
+			},
+			expected: `env: "TOTAL CHAOS" is not a valid environment variable name`,
+		},
+		"probes not a list": {
+			update:   func(arguments []attr.Value) { arguments[5] = types.DynamicValue(types.StringValue("probe")) },
+			expected: "probes: must be a list of probes",
+		},
+		"invalid probes": {
+			update: func(arguments []attr.Value) {
+				first := testHTTPProbe("check", "Always")
+				second := testHTTPProbe("check", "SOT")
+				arguments[5] = types.DynamicValue(types.TupleValueMust(
+					[]attr.Type{first.Type(context.Background()), second.Type(context.Background())},
+					[]attr.Value{first, second},
+				))
+			},
+			expected: "  - probes[0].mode: \"Always\" must be one of SOT, EOT, Edge, Continuous, OnChaos\n" +
+				"  - probes[1].name: \"check\" is used by another probe",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			arguments := testChaosEngineArguments(types.DynamicNull())
+			testCase.update(arguments)
+
+			_, err := testRunFunction(NewChaosEngineFunction(), arguments)
+			if err == nil || !strings.Contains(err.Error(), testCase.expected) {
+				t.Errorf("expected an error containing %q, got: %v", testCase.expected, err)
+			}
+		})
+	}
+}
+
+func TestAccChaosEngineFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "manifest" {
+  value = yamldecode(provider::litmus-chaos::chaos_engine(
+    "nginx-chaos",
+    "litmus",
+    { namespace = "default", label = "app=nginx", kind = "deployment" },
+    "pod-delete",
+    { TOTAL_CHAOS_DURATION = "30" },
+    [
+      {
+        name          = "check-frontend"
+        type          = "httpProbe"
+        mode          = "Continuous"
+        runProperties = { probeTimeout = "5s", interval = "2s" }
+        "httpProbe/inputs" = {
+          url = "http://nginx.default.svc"
+        }
+      },
+    ],
+  )).spec.experiments[0].spec.probe[0].name
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("manifest", "check-frontend"),
+				),
+			},
+		},
+	})
+}
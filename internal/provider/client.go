@@ -0,0 +1,366 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/williamokano/litmus-chaos-thin-client/pkg/entities"
+)
+
+// apiClient holds the connection shared by every litmusAPI implementation.
+// Requests are made here rather than through the thin Litmus Chaos client, as
+// the latter cannot be configured with a custom HTTP transport.
+type apiClient struct {
+	// authEndpoint is the base URL of the authentication server, apiEndpoint
+	// the URL of the GraphQL server query endpoint.
+	authEndpoint string
+	apiEndpoint  string
+
+	tokens     *tokenSource
+	httpClient *http.Client
+
+	// username and password are set when the provider logs in with them, so
+	// that tokens can be issued to other consumers.
+	username string
+	password string
+
+	// redactor masks credentials in logs and in the errors returned.
+	redactor *redactor
+
+	serverInfoMu sync.Mutex
+	serverInfo   *serverInfo
+}
+
+// defaultAuthEndpoint derives the authentication server URL from the host of
+// a control plane exposing both servers behind the same ingress.
+func defaultAuthEndpoint(host string) string {
+	return strings.TrimSuffix(host, "/") + "/auth"
+}
+
+// defaultAPIEndpoint derives the GraphQL server URL from the host of a control
+// plane exposing both servers behind the same ingress, honouring the same
+// environment variable as the thin client for the path.
+func defaultAPIEndpoint(host string) string {
+	graphqlPath := os.Getenv("LITMUS_CHAOS_GRAPHQL_PATH")
+	if graphqlPath == "" {
+		graphqlPath = "/api/query"
+	}
+
+	return strings.TrimSuffix(host, "/") + graphqlPath
+}
+
+// auth calls an endpoint of the authentication server with the client token.
+func (c *apiClient) auth(ctx context.Context, method string, path string, payload any, out any) error {
+	return c.authenticated(ctx, method, c.authEndpoint+path, payload, out)
+}
+
+// authenticated sends an authenticated request. A request rejected with 401
+// is sent once more with a fresh token when the client is able to log in again.
+func (c *apiClient) authenticated(ctx context.Context, method string, url string, payload any, out any) error {
+	ctx = c.redactor.Context(ctx)
+
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return c.redactor.Error(err)
+	}
+
+	err = doJSON(ctx, c.httpClient, method, url, token, payload, out)
+
+	var statusErr *statusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusUnauthorized || !c.tokens.canRefresh() {
+		return c.redactor.Error(err)
+	}
+
+	token, refreshErr := c.tokens.Refresh(ctx, token)
+	if refreshErr != nil {
+		return c.redactor.Error(fmt.Errorf("%w (%s)", err, refreshErr))
+	}
+
+	return c.redactor.Error(doJSON(ctx, c.httpClient, method, url, token, payload, out))
+}
+
+// graphqlResponse is the envelope of every GraphQL response.
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphqlError is returned by graphql when the response carries errors, as
+// opposed to failing to reach the server.
+type graphqlError struct {
+	Messages []string
+}
+
+func (e *graphqlError) Error() string {
+	return "graphql errors: " + strings.Join(e.Messages, "; ")
+}
+
+// graphql runs query against the GraphQL server and decodes its data into out.
+func (c *apiClient) graphql(ctx context.Context, query string, variables map[string]any, out any) error {
+	payload := struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables,omitempty"`
+	}{
+		Query:     query,
+		Variables: variables,
+	}
+
+	var res graphqlResponse
+	if err := c.authenticated(ctx, http.MethodPost, c.apiEndpoint, payload, &res); err != nil {
+		return err
+	}
+
+	if len(res.Errors) > 0 {
+		messages := make([]string, 0, len(res.Errors))
+		for _, e := range res.Errors {
+			messages = append(messages, e.Message)
+		}
+		return c.redactor.Error(&graphqlError{Messages: messages})
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(res.Data, out); err != nil {
+		return fmt.Errorf("failed to decode graphql data: %w", err)
+	}
+
+	return nil
+}
+
+// loginResponse is the body returned by the authentication server on /auth/login.
+type loginResponse struct {
+	AccessToken string `json:"accessToken"`
+}
+
+// login exchanges a username and password for an access token.
+func login(ctx context.Context, httpClient *http.Client, authEndpoint string, username string, password string) (string, error) {
+	payload := struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}{
+		Username: username,
+		Password: password,
+	}
+
+	var res loginResponse
+	if err := doJSON(ctx, httpClient, http.MethodPost, authEndpoint+"/login", "", payload, &res); err != nil {
+		return "", fmt.Errorf("failed to login as %s: %w", username, err)
+	}
+
+	if res.AccessToken == "" {
+		return "", errors.New("login response did not contain an access token")
+	}
+
+	return res.AccessToken, nil
+}
+
+// IssueToken logs in again with the provider username and password, returning
+// a new token independent of the one the provider authenticates with.
+func (c *apiClient) IssueToken(ctx context.Context) (string, error) {
+	if c.username == "" || c.password == "" {
+		return "", errors.New("issuing tokens requires the provider to be configured with a username and password")
+	}
+
+	ctx = c.redactor.Context(ctx)
+
+	token, err := login(ctx, c.httpClient, c.authEndpoint, c.username, c.password)
+	if err != nil {
+		return "", c.redactor.Error(err)
+	}
+	c.redactor.Add(token)
+
+	return token, nil
+}
+
+// UpdatePassword changes the password of username. The authentication server
+// only lets users change their own password, so the request is made with a
+// token obtained from the current credentials of that user.
+func (c *apiClient) UpdatePassword(ctx context.Context, username string, oldPassword string, newPassword string) error {
+	c.redactor.Add(oldPassword, newPassword)
+	ctx = c.redactor.Context(ctx)
+
+	token, err := login(ctx, c.httpClient, c.authEndpoint, username, oldPassword)
+	if err != nil {
+		return c.redactor.Error(err)
+	}
+	c.redactor.Add(token)
+
+	payload := struct {
+		Username    string `json:"username"`
+		OldPassword string `json:"oldPassword"`
+		NewPassword string `json:"newPassword"`
+	}{
+		Username:    username,
+		OldPassword: oldPassword,
+		NewPassword: newPassword,
+	}
+
+	if err := doJSON(ctx, c.httpClient, http.MethodPost, c.authEndpoint+"/update/password", token, payload, nil); err != nil {
+		return c.redactor.Error(fmt.Errorf("failed to update password of %s: %w", username, err))
+	}
+
+	return nil
+}
+
+// ResetPassword sets the password of username without knowing the current
+// one. Only admins are allowed to do this.
+func (c *apiClient) ResetPassword(ctx context.Context, username string, newPassword string) error {
+	c.redactor.Add(newPassword)
+
+	payload := struct {
+		Username    string `json:"username"`
+		NewPassword string `json:"newPassword"`
+	}{
+		Username:    username,
+		NewPassword: newPassword,
+	}
+
+	if err := c.auth(ctx, http.MethodPost, "/reset/password", payload, nil); err != nil {
+		return fmt.Errorf("failed to reset password of %s: %w", username, err)
+	}
+
+	return nil
+}
+
+// authResponse is the envelope of most authentication server responses.
+type authResponse[T any] struct {
+	Data T `json:"data"`
+}
+
+// FetchUsers lists every user of the control plane.
+func (c *apiClient) FetchUsers(ctx context.Context) ([]entities.User, error) {
+	var users []entities.User
+	if err := c.auth(ctx, http.MethodGet, "/users", nil, &users); err != nil {
+		return nil, fmt.Errorf("failed to fetch users: %w", err)
+	}
+
+	return users, nil
+}
+
+// FindUserByUsername looks up a user by its username.
+func (c *apiClient) FindUserByUsername(ctx context.Context, username string) (*entities.User, error) {
+	users, err := c.FetchUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		if user.Username == username {
+			return &user, nil
+		}
+	}
+
+	return nil, fmt.Errorf("couldn't find user with username %s", username)
+}
+
+// CurrentUser returns the identity of the token the client authenticates with.
+func (c *apiClient) CurrentUser(ctx context.Context) (*tokenClaims, error) {
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTokenClaims(token)
+}
+
+// statusError is returned by doJSON when the server answers with a status
+// other than 200.
+type statusError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("expected status_code %d, got %d: %s", http.StatusOK, e.StatusCode, e.Body)
+}
+
+// errNotFound is wrapped by errors of lookups of objects that do not exist,
+// e.g. because they were deleted outside of Terraform.
+var errNotFound = errors.New("not found")
+
+// projectNotFoundMessage is the GraphQL error of project lookups on servers
+// checking for the project before querying it.
+const projectNotFoundMessage = "project not found"
+
+// isNotFound reports whether err is the server saying an object does not
+// exist: a 404 from the authentication server, or a GraphQL error relaying
+// the MongoDB error for a missing document or saying the project is missing.
+// Other GraphQL errors mentioning something not found, such as the user of
+// the request, are real errors.
+func isNotFound(err error) bool {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusNotFound
+	}
+
+	var graphqlErr *graphqlError
+	if errors.As(err, &graphqlErr) {
+		for _, message := range graphqlErr.Messages {
+			if strings.HasSuffix(message, "no documents in result") || message == projectNotFoundMessage {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// doJSON sends payload as a JSON body and decodes the JSON response into out
+// when out is not nil. Any status other than 200 is reported as an error.
+func doJSON(ctx context.Context, httpClient *http.Client, method string, url string, token string, payload any, out any) error {
+	var body io.Reader
+	if payload != nil {
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+		body = bytes.NewReader(payloadBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", method, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute %s request: %w", method, err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return &statusError{StatusCode: res.StatusCode, Body: bytes.TrimSpace(resBody)}
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(resBody, out); err != nil {
+		return fmt.Errorf("failed to decode response body: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,298 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// credentials are the resolved credentials of the provider: a token, a
+// credential helper or a username and password.
+type credentials struct {
+	Token    string
+	Exec     *execSettings
+	Username string
+	Password string
+
+	// Attribute is the attribute the credentials are set by, so that
+	// authentication problems are reported against it.
+	Attribute path.Path
+}
+
+// credentialSource is one of the attributes the token or the password can be
+// set by, along with its environment variable if any.
+type credentialSource struct {
+	attribute string
+	value     types.String
+	env       string
+}
+
+// configured reports whether the source is set in the configuration, an
+// empty string counting as unset as with environment variables.
+func (s credentialSource) configured() bool {
+	return !s.value.IsNull() && s.value.ValueString() != ""
+}
+
+// resolveCredentials picks the credentials of the provider. A credential
+// source set in the configuration always wins over the environment, tokens
+// over passwords on the same level, and setting several tokens or several
+// passwords on the same level is an error.
+func resolveCredentials(ctx context.Context, config litmusChaosProviderModel) (credentials, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	creds := credentials{
+		Username:  os.Getenv("LITMUS_CHAOS_USERNAME"),
+		Attribute: path.Root("password"),
+	}
+	if !config.Username.IsNull() {
+		creds.Username = config.Username.ValueString()
+	}
+
+	tokenSources := []credentialSource{
+		{attribute: "token", value: config.Token, env: "LITMUS_CHAOS_TOKEN"},
+		{attribute: "token_file", value: config.TokenFile, env: "LITMUS_CHAOS_TOKEN_FILE"},
+	}
+	passwordSources := []credentialSource{
+		{attribute: "password", value: config.Password, env: "LITMUS_CHAOS_PASSWORD"},
+		{attribute: "password_file", value: config.PasswordFile, env: "LITMUS_CHAOS_PASSWORD_FILE"},
+	}
+
+	var configuredTokens, configuredPasswords []string
+	for _, source := range tokenSources {
+		if source.configured() {
+			configuredTokens = append(configuredTokens, source.attribute)
+		}
+	}
+	if config.Exec != nil {
+		configuredTokens = append(configuredTokens, "exec")
+	}
+	for _, source := range passwordSources {
+		if source.configured() {
+			configuredPasswords = append(configuredPasswords, source.attribute)
+		}
+	}
+
+	var selected string
+	switch {
+	case len(configuredTokens) > 0:
+		selected = configuredTokens[0]
+		diags.Append(conflictingCredentials(configuredTokens, "the token")...)
+	case len(configuredPasswords) > 0:
+		selected = configuredPasswords[0]
+		diags.Append(conflictingCredentials(configuredPasswords, "the password")...)
+	default:
+		var envTokens, envPasswords []string
+		for _, source := range tokenSources {
+			if os.Getenv(source.env) != "" {
+				envTokens = append(envTokens, source.env)
+			}
+		}
+		for _, source := range passwordSources {
+			if os.Getenv(source.env) != "" {
+				envPasswords = append(envPasswords, source.env)
+			}
+		}
+
+		switch {
+		case len(envTokens) > 0:
+			selected = credentialSourceOf(tokenSources, envTokens[0]).attribute
+			diags.Append(conflictingCredentials(envTokens, "the token")...)
+		case len(envPasswords) > 0:
+			selected = credentialSourceOf(passwordSources, envPasswords[0]).attribute
+			diags.Append(conflictingCredentials(envPasswords, "the password")...)
+		}
+	}
+
+	if diags.HasError() || selected == "" {
+		return creds, diags
+	}
+
+	creds.Attribute = path.Root(selected)
+
+	switch selected {
+	case "token":
+		creds.Token = credentialSourceOf(tokenSources, "token").setting()
+	case "token_file":
+		token, err := readCredentialFile(credentialSourceOf(tokenSources, "token_file").setting())
+		if err != nil {
+			diags.AddAttributeError(
+				creds.Attribute,
+				"Unable to Read Litmus Chaos API Token",
+				"The provider cannot read the Litmus Chaos API token from token_file: "+err.Error(),
+			)
+		}
+		creds.Token = token
+	case "password":
+		creds.Password = credentialSourceOf(passwordSources, "password").setting()
+	case "password_file":
+		password, err := readCredentialFile(credentialSourceOf(passwordSources, "password_file").setting())
+		if err != nil {
+			diags.AddAttributeError(
+				creds.Attribute,
+				"Unable to Read Litmus Chaos API Password",
+				"The provider cannot read the Litmus Chaos API password from password_file: "+err.Error(),
+			)
+		}
+		creds.Password = password
+	case "exec":
+		creds.Exec = &execSettings{
+			Command: config.Exec.Command.ValueString(),
+			Env:     map[string]string{},
+		}
+
+		if creds.Exec.Command == "" {
+			diags.AddAttributeError(
+				creds.Attribute.AtName("command"),
+				"Missing Litmus Chaos Credential Helper Command",
+				"The exec block must set the command of the credential helper.",
+			)
+		}
+
+		if !config.Exec.Args.IsNull() {
+			diags.Append(config.Exec.Args.ElementsAs(ctx, &creds.Exec.Args, false)...)
+		}
+
+		if !config.Exec.Env.IsNull() {
+			diags.Append(config.Exec.Env.ElementsAs(ctx, &creds.Exec.Env, false)...)
+		}
+
+		if !config.Exec.SensitiveEnv.IsNull() {
+			diags.Append(config.Exec.SensitiveEnv.ElementsAs(ctx, &creds.Exec.SensitiveEnv, false)...)
+		}
+
+		for _, name := range creds.Exec.SensitiveEnv {
+			if _, ok := creds.Exec.Env[name]; !ok {
+				diags.AddAttributeError(
+					creds.Attribute.AtName("sensitive_env"),
+					"Invalid Litmus Chaos Credential Helper Environment",
+					"The "+name+" variable is listed in sensitive_env but not configured in env.",
+				)
+			}
+		}
+	}
+
+	return creds, diags
+}
+
+// credentialSourceOf returns the source of sources with the attribute or the
+// environment variable name.
+func credentialSourceOf(sources []credentialSource, name string) credentialSource {
+	for _, source := range sources {
+		if source.attribute == name || source.env == name {
+			return source
+		}
+	}
+
+	return credentialSource{}
+}
+
+// setting returns the value of the source, from the configuration or else
+// from the environment.
+func (s credentialSource) setting() string {
+	if s.configured() {
+		return s.value.ValueString()
+	}
+
+	return os.Getenv(s.env)
+}
+
+// conflictingCredentials reports several sources set for the same secret.
+func conflictingCredentials(sources []string, secret string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if len(sources) > 1 {
+		diags.AddError(
+			"Conflicting Litmus Chaos Credentials",
+			"The provider cannot tell which credentials to use as "+secret+" is set by "+strings.Join(sources, ", ")+". "+
+				"Set only one of them.",
+		)
+	}
+
+	return diags
+}
+
+// readCredentialFile reads a secret from a file, ignoring surrounding
+// whitespace such as the trailing newline most tools write.
+func readCredentialFile(name string) (string, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+
+	secret := strings.TrimSpace(string(content))
+	if secret == "" {
+		return "", fmt.Errorf("%s is empty", name)
+	}
+
+	return secret, nil
+}
+
+// execSettings holds the configuration of the credential helper the token is
+// obtained from.
+type execSettings struct {
+	Command string
+	Args    []string
+	Env     map[string]string
+
+	// SensitiveEnv names the variables of Env holding secrets
+	SensitiveEnv []string
+}
+
+// execCredential is the JSON document a credential helper prints on stdout.
+// The expiry is optional; without it the expiry of the token itself applies.
+type execCredential struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// execTokenSource returns a token source running the credential helper
+// whenever a new token is needed. The token is cached until the expiry the
+// helper reports.
+func execTokenSource(settings execSettings) *tokenSource {
+	return &tokenSource{
+		refresh: settings.token,
+	}
+}
+
+// token runs the credential helper and decodes its output. The helper
+// inherits the provider environment, extended with the configured variables.
+func (s execSettings) token(ctx context.Context) (string, time.Time, error) {
+	cmd := exec.CommandContext(ctx, s.Command, s.Args...) //nolint:gosec // running the configured helper is the point
+	cmd.Env = os.Environ()
+	for name, value := range s.Env {
+		cmd.Env = append(cmd.Env, name+"="+value)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", time.Time{}, fmt.Errorf("credential helper %s failed: %w: %s", s.Command, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	var credential execCredential
+	if err := json.Unmarshal(stdout.Bytes(), &credential); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to decode output of credential helper %s: %w", s.Command, err)
+	}
+
+	if credential.Token == "" {
+		return "", time.Time{}, errors.New("credential helper " + s.Command + " did not return a token")
+	}
+
+	if credential.ExpiresAt.IsZero() {
+		return credential.Token, tokenExpiry(credential.Token), nil
+	}
+
+	return credential.Token, credential.ExpiresAt, nil
+}
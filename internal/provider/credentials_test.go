@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestReadCredentialFile(t *testing.T) {
+	dir := t.TempDir()
+
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("  token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if actual, err := readCredentialFile(tokenFile); err != nil || actual != "token" {
+		t.Errorf("expected token, got %q (%v)", actual, err)
+	}
+
+	if _, err := readCredentialFile(emptyFile); err == nil {
+		t.Error("expected an error for an empty file")
+	}
+
+	if _, err := readCredentialFile(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestResolveCredentials(t *testing.T) {
+	dir := t.TempDir()
+
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	passwordFile := filepath.Join(dir, "password")
+	if err := os.WriteFile(passwordFile, []byte("file-password\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		config            litmusChaosProviderModel
+		env               map[string]string
+		expectedToken     string
+		expectedPassword  string
+		expectedExec      bool
+		expectedAttribute string
+		expectedError     string
+	}{
+		"nothing set": {
+			expectedAttribute: "password",
+		},
+		"token": {
+			config:            litmusChaosProviderModel{Token: types.StringValue("config-token")},
+			expectedToken:     "config-token",
+			expectedAttribute: "token",
+		},
+		"token over environment token": {
+			config:            litmusChaosProviderModel{Token: types.StringValue("config-token")},
+			env:               map[string]string{"LITMUS_CHAOS_TOKEN": "env-token"},
+			expectedToken:     "config-token",
+			expectedAttribute: "token",
+		},
+		"token_file over environment token": {
+			config:            litmusChaosProviderModel{TokenFile: types.StringValue(tokenFile)},
+			env:               map[string]string{"LITMUS_CHAOS_TOKEN": "env-token"},
+			expectedToken:     "file-token",
+			expectedAttribute: "token_file",
+		},
+		"exec over environment token": {
+			config:            litmusChaosProviderModel{Exec: &providerExecModel{Command: types.StringValue("helper")}},
+			env:               map[string]string{"LITMUS_CHAOS_TOKEN": "env-token"},
+			expectedExec:      true,
+			expectedAttribute: "exec",
+		},
+		"password over environment token": {
+			config:            litmusChaosProviderModel{Password: types.StringValue("config-password")},
+			env:               map[string]string{"LITMUS_CHAOS_TOKEN": "env-token"},
+			expectedPassword:  "config-password",
+			expectedAttribute: "password",
+		},
+		"password_file over environment password": {
+			config:            litmusChaosProviderModel{PasswordFile: types.StringValue(passwordFile)},
+			env:               map[string]string{"LITMUS_CHAOS_PASSWORD": "env-password"},
+			expectedPassword:  "file-password",
+			expectedAttribute: "password_file",
+		},
+		"token over password": {
+			config: litmusChaosProviderModel{
+				Token:    types.StringValue("config-token"),
+				Password: types.StringValue("config-password"),
+			},
+			expectedToken:     "config-token",
+			expectedAttribute: "token",
+		},
+		"empty token is unset": {
+			config:            litmusChaosProviderModel{Token: types.StringValue("")},
+			env:               map[string]string{"LITMUS_CHAOS_TOKEN": "env-token"},
+			expectedToken:     "env-token",
+			expectedAttribute: "token",
+		},
+		"environment token over environment password": {
+			env: map[string]string{
+				"LITMUS_CHAOS_TOKEN":    "env-token",
+				"LITMUS_CHAOS_PASSWORD": "env-password",
+			},
+			expectedToken:     "env-token",
+			expectedAttribute: "token",
+		},
+		"environment token_file": {
+			env:               map[string]string{"LITMUS_CHAOS_TOKEN_FILE": tokenFile},
+			expectedToken:     "file-token",
+			expectedAttribute: "token_file",
+		},
+		"environment password_file": {
+			env:               map[string]string{"LITMUS_CHAOS_PASSWORD_FILE": passwordFile},
+			expectedPassword:  "file-password",
+			expectedAttribute: "password_file",
+		},
+		"token and token_file": {
+			config: litmusChaosProviderModel{
+				Token:     types.StringValue("config-token"),
+				TokenFile: types.StringValue(tokenFile),
+			},
+			expectedError: "the token is set by token, token_file",
+		},
+		"token and exec": {
+			config: litmusChaosProviderModel{
+				Token: types.StringValue("config-token"),
+				Exec:  &providerExecModel{Command: types.StringValue("helper")},
+			},
+			expectedError: "the token is set by token, exec",
+		},
+		"password and password_file": {
+			config: litmusChaosProviderModel{
+				Password:     types.StringValue("config-password"),
+				PasswordFile: types.StringValue(passwordFile),
+			},
+			expectedError: "the password is set by password, password_file",
+		},
+		"environment token and token_file": {
+			env: map[string]string{
+				"LITMUS_CHAOS_TOKEN":      "env-token",
+				"LITMUS_CHAOS_TOKEN_FILE": tokenFile,
+			},
+			expectedError: "the token is set by LITMUS_CHAOS_TOKEN, LITMUS_CHAOS_TOKEN_FILE",
+		},
+		"sensitive_env not in env": {
+			config: litmusChaosProviderModel{Exec: &providerExecModel{
+				Command:      types.StringValue("helper"),
+				SensitiveEnv: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("VAULT_TOKEN")}),
+			}},
+			expectedError: "The VAULT_TOKEN variable is listed in sensitive_env but not configured in env.",
+		},
+		"missing token_file": {
+			config:        litmusChaosProviderModel{TokenFile: types.StringValue(filepath.Join(dir, "missing"))},
+			expectedError: "cannot read the Litmus Chaos API token from token_file",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for _, env := range []string{"LITMUS_CHAOS_TOKEN", "LITMUS_CHAOS_TOKEN_FILE", "LITMUS_CHAOS_PASSWORD", "LITMUS_CHAOS_PASSWORD_FILE"} {
+				t.Setenv(env, testCase.env[env])
+			}
+
+			creds, diags := resolveCredentials(context.Background(), testCase.config)
+
+			testCheckDiagnostics(t, diags, testCase.expectedError)
+			if testCase.expectedError != "" {
+				return
+			}
+
+			if creds.Token != testCase.expectedToken {
+				t.Errorf("expected token %q, got %q", testCase.expectedToken, creds.Token)
+			}
+			if creds.Password != testCase.expectedPassword {
+				t.Errorf("expected password %q, got %q", testCase.expectedPassword, creds.Password)
+			}
+			if (creds.Exec != nil) != testCase.expectedExec {
+				t.Errorf("expected credential helper %t, got %+v", testCase.expectedExec, creds.Exec)
+			}
+			if !creds.Attribute.Equal(path.Root(testCase.expectedAttribute)) {
+				t.Errorf("expected attribute %s, got %s", testCase.expectedAttribute, creds.Attribute)
+			}
+		})
+	}
+}
+
+func TestExecTokenSource(t *testing.T) {
+	testCases := map[string]struct {
+		expiresAt    time.Time
+		expectedRuns int
+	}{
+		"cached until expiry": {expiresAt: time.Now().Add(time.Hour), expectedRuns: 1},
+		"expired":             {expiresAt: time.Now().Add(-time.Minute), expectedRuns: 2},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			runs := filepath.Join(t.TempDir(), "runs")
+
+			tokens := execTokenSource(execSettings{
+				Command: "sh",
+				Args:    []string{"-c", `echo run >> "$RUNS" && printf '{"token":"%s","expires_at":"%s"}' "$TOKEN" "$EXPIRES_AT"`},
+				Env: map[string]string{
+					"RUNS":       runs,
+					"TOKEN":      "helper-token",
+					"EXPIRES_AT": testCase.expiresAt.Format(time.RFC3339),
+				},
+			})
+
+			for i := 0; i < 2; i++ {
+				token, err := tokens.Token(context.Background())
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if token != "helper-token" {
+					t.Errorf("expected helper-token, got %q", token)
+				}
+			}
+
+			content, _ := os.ReadFile(runs)
+			if actual := strings.Count(string(content), "run"); actual != testCase.expectedRuns {
+				t.Errorf("expected %d runs, got %d", testCase.expectedRuns, actual)
+			}
+		})
+	}
+}
+
+func TestExecTokenSourceErrors(t *testing.T) {
+	for name, args := range map[string][]string{
+		"failing command": {"-c", "echo denied >&2; exit 1"},
+		"invalid output":  {"-c", "echo token"},
+		"no token":        {"-c", `echo '{}'`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := execTokenSource(execSettings{Command: "sh", Args: args}).Token(context.Background())
+			if err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &currentUserDataSource{}
+	_ datasource.DataSourceWithConfigure = &currentUserDataSource{}
+)
+
+type currentUserDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Username       types.String `tfsdk:"username"`
+	Role           types.String `tfsdk:"role"`
+	TokenExpiresAt types.String `tfsdk:"token_expires_at"`
+}
+
+type currentUserDataSource struct {
+	client litmusAPI
+}
+
+func NewCurrentUserDataSource() datasource.DataSource {
+	return &currentUserDataSource{}
+}
+
+func (d *currentUserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data, diags := providerDataFrom(req.ProviderData, "Data Source")
+	resp.Diagnostics.Append(diags...)
+	if data == nil {
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *currentUserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_current_user"
+}
+
+func (d *currentUserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Identity the provider is authenticated as, whether it was configured with a token or with username and password.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "User ID",
+				Computed:    true,
+			},
+			"username": schema.StringAttribute{
+				Description: "User username",
+				Computed:    true,
+			},
+			"role": schema.StringAttribute{
+				Description: "User role",
+				Computed:    true,
+			},
+			"token_expires_at": schema.StringAttribute{
+				Description: "Expiry of the token in use, in RFC 3339 format. Empty if the token does not expire.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *currentUserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state currentUserDataSourceModel
+
+	claims, err := d.client.CurrentUser(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Litmus Chaos Current User",
+			"Could not identify the user of the configured credentials: "+err.Error(),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(claims.UserID)
+	state.Username = types.StringValue(claims.Username)
+	state.Role = types.StringValue(claims.Role)
+	state.TokenExpiresAt = types.StringValue("")
+
+	if expiry := claims.Expiry(); !expiry.IsZero() {
+		state.TokenExpiresAt = types.StringValue(expiry.Format(time.RFC3339))
+	}
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
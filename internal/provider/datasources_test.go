@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccUserDataSources(t *testing.T) {
+	testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "litmus-chaos_current_user" "current" {}
+
+data "litmus-chaos_user" "current" {
+  username = data.litmus-chaos_current_user.current.username
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.litmus-chaos_current_user.current", "id"),
+					resource.TestCheckResourceAttrPair("data.litmus-chaos_user.current", "id", "data.litmus-chaos_current_user.current", "id"),
+					resource.TestCheckResourceAttrPair("data.litmus-chaos_user.current", "role", "data.litmus-chaos_current_user.current", "role"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerInfoDataSource(t *testing.T) {
+	testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "litmus-chaos_server_info" "current" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.litmus-chaos_server_info.current", "server_version"),
+					resource.TestCheckResourceAttr("data.litmus-chaos_server_info.current", "ready", "true"),
+					resource.TestCheckResourceAttrSet("data.litmus-chaos_server_info.current", "capabilities.project_deletion"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,360 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/williamokano/litmus-chaos-thin-client/pkg/entities"
+	litmusgraphql "github.com/williamokano/litmus-chaos-thin-client/pkg/graphql"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &environmentResource{}
+	_ resource.ResourceWithConfigure      = &environmentResource{}
+	_ resource.ResourceWithImportState    = &environmentResource{}
+	_ resource.ResourceWithModifyPlan     = &environmentResource{}
+	_ resource.ResourceWithValidateConfig = &environmentResource{}
+)
+
+// environmentTypes are the types of environment the server accepts.
+var environmentTypes = []entities.EnvironmentType{
+	entities.EnvironmentTypeProd,
+	entities.EnvironmentTypeNonProd,
+}
+
+// environmentResource is the resource implementation.
+type environmentResource struct {
+	data *providerData
+}
+
+type environmentResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	ProjectID   types.String   `tfsdk:"project_id"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Type        types.String   `tfsdk:"type"`
+	LastUpdated types.String   `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewEnvironmentResource() resource.Resource {
+	return &environmentResource{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *environmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data, diags := providerDataFrom(req.ProviderData, "Resource")
+	resp.Diagnostics.Append(diags...)
+	if data == nil {
+		return
+	}
+
+	r.data = data
+}
+
+// Metadata returns the resource type name.
+func (r *environmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment"
+}
+
+// Schema defines the schema for the resource.
+func (r *environmentResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Litmus Chaos environment, which groups the chaos infrastructures of a project. " +
+			"Requires Litmus Chaos 3.0 or later. Environments can be imported with a `<project_id>/<environment_id>` import ID.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Environment ID, derived from the name on creation as the ChaosCenter UI does",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				Description: "ID of the project the environment belongs to. Defaults to the provider `project_id`; " +
+					"changing either replaces the environment.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					// Changes of the provider default are planned by ModifyPlan
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the environment",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the environment. Defaults to an empty string.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the environment, either `PROD` or `NON_PROD`",
+				Required:    true,
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Date of last modification",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ValidateConfig ensures the type is one the server accepts.
+func (r *environmentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var environmentType types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("type"), &environmentType)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || environmentType.IsNull() || environmentType.IsUnknown() {
+		return
+	}
+
+	names := make([]string, 0, len(environmentTypes))
+	for _, t := range environmentTypes {
+		if environmentType.ValueString() == string(t) {
+			return
+		}
+		names = append(names, string(t))
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("type"),
+		"Invalid Litmus Chaos Environment Type",
+		"The type must be one of "+strings.Join(names, ", ")+", got: "+environmentType.ValueString(),
+	)
+}
+
+// ModifyPlan plans the project the environment belongs to, and reports
+// servers without environments when planning rather than halfway through the
+// apply.
+func (r *environmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when destroying, or when the provider is not
+	// configured yet, as happens during validation.
+	if req.Plan.Raw.IsNull() || r.data == nil {
+		return
+	}
+
+	r.data.planProjectID(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(requireCapability(ctx, r.data.client, capabilityEnvironments, "Environment management")...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *environmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan environmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	environment, err := r.data.client.CreateEnvironment(ctx, plan.ProjectID.ValueString(), litmusgraphql.CreateEnvironmentRequest{
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+		Type:        entities.EnvironmentType(plan.Type.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Litmus Chaos Environment",
+			"Could not create environment in project "+plan.ProjectID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(environment.EnvironmentID)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state environmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	environment, err := r.data.client.GetEnvironment(ctx, state.ProjectID.ValueString(), state.ID.ValueString())
+	if errors.Is(err, errNotFound) {
+		// Deleted outside of Terraform, so it is planned for creation again
+		tflog.Warn(ctx, "Litmus Chaos environment not found, removing it from the state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Litmus Chaos Environment",
+			"Could not read Litmus Chaos Environment ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.setEnvironment(environment)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *environmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan environmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	_, err := r.data.client.UpdateEnvironment(ctx, plan.ProjectID.ValueString(), litmusgraphql.UpdateEnvironmentRequest{
+		EnvironmentID: plan.ID.ValueString(),
+		Name:          plan.Name.ValueString(),
+		Description:   plan.Description.ValueString(),
+		Type:          entities.EnvironmentType(plan.Type.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Litmus Chaos Environment",
+			"Could not update Litmus Chaos environment with ID "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state environmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.data.client.DeleteEnvironment(ctx, state.ProjectID.ValueString(), state.ID.ValueString())
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting Litmus Chaos Environment",
+			"Could not delete Litmus Chaos environment with ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the resource to the Terraform state, from a
+// "<project_id>/<environment_id>" import ID.
+func (r *environmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, environmentID, ok := strings.Cut(req.ID, "/")
+	if !ok || projectID == "" || environmentID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Litmus Chaos Environment Import ID",
+			"Expected an import ID of the form <project_id>/<environment_id>, got: "+req.ID,
+		)
+		return
+	}
+
+	environment, err := r.data.client.GetEnvironment(ctx, projectID, environmentID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing Litmus Chaos Environment",
+			"Could not import Litmus Chaos environment "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	var state environmentResourceModel
+	state.setEnvironment(environment)
+	state.ProjectID = types.StringValue(projectID)
+	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	state.Timeouts = nullTimeouts()
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// setEnvironment copies the attributes the server reports into the model.
+func (m *environmentResourceModel) setEnvironment(environment *entities.Environment) {
+	m.ID = types.StringValue(environment.EnvironmentID)
+	m.Name = types.StringValue(environment.Name)
+	m.Description = types.StringValue(environment.Description)
+	m.Type = types.StringValue(string(environment.Type))
+}
@@ -0,0 +1,468 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/williamokano/litmus-chaos-thin-client/pkg/entities"
+	"github.com/williamokano/terraform-provider-litmus-chaos/internal/fakelitmus"
+)
+
+func TestAccEnvironmentResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if server == nil {
+				return nil
+			}
+			for _, rs := range s.RootModule().Resources {
+				if rs.Type != "litmus-chaos_environment" {
+					continue
+				}
+				if _, ok := server.Environment(rs.Primary.Attributes["project_id"], rs.Primary.ID); ok {
+					return fmt.Errorf("environment %s still exists", rs.Primary.ID)
+				}
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "litmus-chaos_project" "main_project" {
+  name = "tf-acc-environment-project"
+}
+
+resource "litmus-chaos_environment" "staging" {
+  project_id = litmus-chaos_project.main_project.id
+  name       = "tf acc staging"
+  type       = "NON_PROD"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("litmus-chaos_environment.staging", "id", "tf_acc_staging"),
+					resource.TestCheckResourceAttr("litmus-chaos_environment.staging", "name", "tf acc staging"),
+					resource.TestCheckResourceAttr("litmus-chaos_environment.staging", "description", ""),
+					resource.TestCheckResourceAttr("litmus-chaos_environment.staging", "type", "NON_PROD"),
+					resource.TestCheckResourceAttrSet("litmus-chaos_environment.staging", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName: "litmus-chaos_environment.staging",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["litmus-chaos_environment.staging"]
+					return rs.Primary.Attributes["project_id"] + "/" + rs.Primary.ID, nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "litmus-chaos_project" "main_project" {
+  name = "tf-acc-environment-project"
+}
+
+resource "litmus-chaos_environment" "staging" {
+  project_id  = litmus-chaos_project.main_project.id
+  name        = "tf acc production"
+  description = "Promoted"
+  type        = "PROD"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("litmus-chaos_environment.staging", "id", "tf_acc_staging"),
+					resource.TestCheckResourceAttr("litmus-chaos_environment.staging", "name", "tf acc production"),
+					resource.TestCheckResourceAttr("litmus-chaos_environment.staging", "description", "Promoted"),
+					resource.TestCheckResourceAttr("litmus-chaos_environment.staging", "type", "PROD"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccEnvironmentResourceUnsupported(t *testing.T) {
+	server := testAccServer(t, fakelitmus.WithVersion("2.14.0"))
+	if server == nil {
+		t.Skip("needs the fake control plane to report a 2.x version")
+	}
+	project := server.AddProject("tf-acc-environment-project")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "litmus-chaos_environment" "staging" {
+  project_id = %q
+  name       = "staging"
+  type       = "NON_PROD"
+}
+`, project.ID),
+				ExpectError: regexp.MustCompile(`Environment management is not supported on Litmus Chaos server\s+version\s+"2.14.0"`),
+			},
+		},
+	})
+}
+
+func TestAccEnvironmentResourceDefaultProjectID(t *testing.T) {
+	server := testAccServer(t)
+	if server == nil {
+		t.Skip("needs projects created on the fake control plane")
+	}
+	project := server.AddProject("tf-acc-default-project")
+	otherProject := server.AddProject("tf-acc-other-project")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Environments created with their own project_id
+			{
+				Config: fmt.Sprintf(`
+provider "litmus-chaos" {}
+
+resource "litmus-chaos_environment" "staging" {
+  project_id = %q
+  name       = "staging"
+  type       = "NON_PROD"
+}
+`, project.ID),
+				Check: resource.TestCheckResourceAttr("litmus-chaos_environment.staging", "project_id", project.ID),
+			},
+			// Moving project_id to the provider has no diff
+			{
+				Config: fmt.Sprintf(`
+provider "litmus-chaos" {
+  project_id = %q
+}
+
+resource "litmus-chaos_environment" "staging" {
+  name = "staging"
+  type = "NON_PROD"
+}
+`, project.ID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Changing the provider project_id replaces the environment
+			{
+				Config: fmt.Sprintf(`
+provider "litmus-chaos" {
+  project_id = %q
+}
+
+resource "litmus-chaos_environment" "staging" {
+  name = "staging"
+  type = "NON_PROD"
+}
+`, otherProject.ID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("litmus-chaos_environment.staging", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: func(_ *terraform.State) error {
+					if _, ok := server.Environment(project.ID, "staging"); ok {
+						return fmt.Errorf("expected the environment of project %s to be deleted", project.ID)
+					}
+					if _, ok := server.Environment(otherProject.ID, "staging"); !ok {
+						return fmt.Errorf("expected the environment to be created in project %s", otherProject.ID)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testEnvironmentResourceSchema(t *testing.T) rschema.Schema {
+	t.Helper()
+
+	resp := &fwresource.SchemaResponse{}
+	NewEnvironmentResource().Schema(context.Background(), fwresource.SchemaRequest{}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	return resp.Schema
+}
+
+// testEnvironmentResourceValue returns an environment resource object of s
+// with the given string attributes.
+func testEnvironmentResourceValue(t *testing.T, s rschema.Schema, values map[string]string) tftypes.Value {
+	t.Helper()
+
+	attributes := make(map[string]tftypes.Value, len(values))
+	for name, value := range values {
+		attributes[name] = tftypes.NewValue(tftypes.String, value)
+	}
+
+	return testObject(t, s.Type().TerraformType(context.Background()), attributes)
+}
+
+func TestEnvironmentResourceValidateConfig(t *testing.T) {
+	testCases := map[string]struct {
+		environmentType string
+		expectedError   string
+	}{
+		"production":     {environmentType: "PROD"},
+		"non production": {environmentType: "NON_PROD"},
+		"invalid": {
+			environmentType: "staging",
+			expectedError:   "The type must be one of PROD, NON_PROD, got: staging",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := testEnvironmentResourceSchema(t)
+			r := &environmentResource{}
+
+			resp := &fwresource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, fwresource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: s, Raw: testEnvironmentResourceValue(t, s, map[string]string{
+					"project_id": "project-id",
+					"name":       "staging",
+					"type":       testCase.environmentType,
+				})},
+			}, resp)
+
+			testCheckDiagnostics(t, resp.Diagnostics, testCase.expectedError)
+		})
+	}
+}
+
+func TestEnvironmentResourceModifyPlan(t *testing.T) {
+	supported := func(context.Context) (*serverInfo, error) {
+		return &serverInfo{Version: "3.9.0", Capabilities: capabilitiesForVersion("3.9.0")}, nil
+	}
+
+	testCases := map[string]struct {
+		api               mockAPI
+		defaultProjectID  string
+		configProjectID   string
+		stateProjectID    string
+		expectedProjectID string
+		expectedReplace   bool
+		expectedError     string
+	}{
+		"configured project": {
+			api:               mockAPI{serverInfo: supported},
+			defaultProjectID:  "default-id",
+			configProjectID:   "project-id",
+			expectedProjectID: "project-id",
+		},
+		"inherited project": {
+			api:               mockAPI{serverInfo: supported},
+			defaultProjectID:  "default-id",
+			expectedProjectID: "default-id",
+		},
+		"inherited project unchanged": {
+			api:               mockAPI{serverInfo: supported},
+			defaultProjectID:  "default-id",
+			stateProjectID:    "default-id",
+			expectedProjectID: "default-id",
+		},
+		"inherited project changed": {
+			api:               mockAPI{serverInfo: supported},
+			defaultProjectID:  "other-id",
+			stateProjectID:    "default-id",
+			expectedProjectID: "other-id",
+			expectedReplace:   true,
+		},
+		"missing project": {
+			api:           mockAPI{serverInfo: supported},
+			expectedError: "The project_id attribute must be set as the provider does not configure a default project_id.",
+		},
+		"unsupported": {
+			api: mockAPI{
+				serverInfo: func(context.Context) (*serverInfo, error) {
+					return &serverInfo{Version: "2.14.0", Capabilities: capabilitiesForVersion("2.14.0")}, nil
+				},
+			},
+			configProjectID: "project-id",
+			expectedError:   `Environment management is not supported on Litmus Chaos server version "2.14.0".`,
+		},
+		"version unknown": {
+			api: mockAPI{
+				serverInfo: func(context.Context) (*serverInfo, error) {
+					return nil, errors.New("connection refused")
+				},
+			},
+			configProjectID: "project-id",
+			expectedError:   "Could not check that the server supports environment management: connection refused",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := testEnvironmentResourceSchema(t)
+			r := &environmentResource{data: newProviderData(&testCase.api, testCase.defaultProjectID)}
+
+			values := map[string]string{
+				"name": "staging",
+				"type": "NON_PROD",
+			}
+			if testCase.configProjectID != "" {
+				values["project_id"] = testCase.configProjectID
+			}
+			config := tfsdk.Config{Schema: s, Raw: testEnvironmentResourceValue(t, s, values)}
+
+			// As planned by the attribute plan modifiers
+			state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+			planned := map[string]tftypes.Value{
+				"name":       tftypes.NewValue(tftypes.String, "staging"),
+				"type":       tftypes.NewValue(tftypes.String, "NON_PROD"),
+				"project_id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			}
+			if testCase.configProjectID != "" {
+				planned["project_id"] = tftypes.NewValue(tftypes.String, testCase.configProjectID)
+			}
+			if testCase.stateProjectID != "" {
+				values["id"] = "staging"
+				values["project_id"] = testCase.stateProjectID
+				state.Raw = testEnvironmentResourceValue(t, s, values)
+				planned["id"] = tftypes.NewValue(tftypes.String, "staging")
+				if testCase.configProjectID == "" {
+					planned["project_id"] = tftypes.NewValue(tftypes.String, testCase.stateProjectID)
+				}
+			}
+			plan := tfsdk.Plan{Schema: s, Raw: testObject(t, s.Type().TerraformType(ctx), planned)}
+
+			resp := &fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Config: config, Plan: plan, State: state}, resp)
+
+			testCheckDiagnostics(t, resp.Diagnostics, testCase.expectedError)
+			if testCase.expectedError != "" {
+				return
+			}
+
+			var projectID types.String
+			resp.Plan.GetAttribute(ctx, path.Root("project_id"), &projectID)
+			if projectID.ValueString() != testCase.expectedProjectID {
+				t.Errorf("expected project_id to be planned as %q, got %s", testCase.expectedProjectID, projectID)
+			}
+
+			if replace := len(resp.RequiresReplace) > 0; replace != testCase.expectedReplace {
+				t.Errorf("expected replace to be %t, got %v", testCase.expectedReplace, resp.RequiresReplace)
+			}
+		})
+	}
+}
+
+func TestEnvironmentResourceRead(t *testing.T) {
+	testCases := map[string]struct {
+		api           mockAPI
+		expectedState map[string]string
+		expectedError string
+	}{
+		"refreshed": {
+			api: mockAPI{
+				getEnvironment: func(_ context.Context, projectID string, environmentID string) (*entities.Environment, error) {
+					return &entities.Environment{ProjectID: projectID, EnvironmentID: environmentID, Name: "renamed", Type: entities.EnvironmentTypeProd}, nil
+				},
+			},
+			expectedState: map[string]string{"id": "staging", "project_id": "project-id", "name": "renamed", "type": "PROD"},
+		},
+		"deleted out of band": {
+			api: mockAPI{
+				getEnvironment: func(context.Context, string, string) (*entities.Environment, error) {
+					return nil, fmt.Errorf("failed to get environment by id: %w", errNotFound)
+				},
+			},
+		},
+		"api error": {
+			api: mockAPI{
+				getEnvironment: func(context.Context, string, string) (*entities.Environment, error) {
+					return nil, errors.New("connection refused")
+				},
+			},
+			expectedError: "Could not read Litmus Chaos Environment ID staging: connection refused",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := testEnvironmentResourceSchema(t)
+			r := &environmentResource{data: newProviderData(&testCase.api, "")}
+
+			state := tfsdk.State{Schema: s, Raw: testEnvironmentResourceValue(t, s, map[string]string{
+				"id":         "staging",
+				"project_id": "project-id",
+				"name":       "staging",
+				"type":       "NON_PROD",
+			})}
+			resp := &fwresource.ReadResponse{State: state}
+			r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+
+			testCheckDiagnostics(t, resp.Diagnostics, testCase.expectedError)
+			if testCase.expectedError != "" {
+				return
+			}
+
+			testCheckState(t, resp.State, testCase.expectedState)
+		})
+	}
+}
+
+func TestEnvironmentResourceImportState(t *testing.T) {
+	testCases := map[string]struct {
+		importID      string
+		expectedState map[string]string
+		expectedError string
+	}{
+		"project and environment": {
+			importID:      "project-id/staging",
+			expectedState: map[string]string{"id": "staging", "project_id": "project-id", "name": "staging"},
+		},
+		"environment only": {
+			importID:      "staging",
+			expectedError: "Expected an import ID of the form <project_id>/<environment_id>, got: staging",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := testEnvironmentResourceSchema(t)
+			r := &environmentResource{data: newProviderData(&mockAPI{
+				getEnvironment: func(_ context.Context, projectID string, environmentID string) (*entities.Environment, error) {
+					return &entities.Environment{ProjectID: projectID, EnvironmentID: environmentID, Name: environmentID, Type: entities.EnvironmentTypeNonProd}, nil
+				},
+			}, "")}
+
+			resp := &fwresource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}}
+			r.ImportState(ctx, fwresource.ImportStateRequest{ID: testCase.importID}, resp)
+
+			testCheckDiagnostics(t, resp.Diagnostics, testCase.expectedError)
+			if testCase.expectedError != "" {
+				return
+			}
+
+			testCheckState(t, resp.State, testCase.expectedState)
+		})
+	}
+}
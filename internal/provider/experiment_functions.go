@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = &parseExperimentFunction{}
+	_ function.Function = &validateExperimentFunction{}
+)
+
+var experimentStepType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name":     types.StringType,
+	"template": types.StringType,
+}}
+
+var experimentProbeType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name":  types.StringType,
+	"type":  types.StringType,
+	"mode":  types.StringType,
+	"fault": types.StringType,
+}}
+
+var experimentFaultType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name":     types.StringType,
+	"template": types.StringType,
+	"app": types.ObjectType{AttrTypes: map[string]attr.Type{
+		"namespace": types.StringType,
+		"label":     types.StringType,
+		"kind":      types.StringType,
+	}},
+	"env": types.MapType{ElemType: types.StringType},
+}}
+
+// experimentModel is the result of parse_experiment.
+type experimentModel struct {
+	Name     string                  `tfsdk:"name"`
+	Kind     string                  `tfsdk:"kind"`
+	Schedule types.String            `tfsdk:"schedule"`
+	Steps    [][]experimentStepModel `tfsdk:"steps"`
+	Faults   []experimentFaultModel  `tfsdk:"faults"`
+	Probes   []experimentProbeModel  `tfsdk:"probes"`
+}
+
+type experimentStepModel struct {
+	Name     string `tfsdk:"name"`
+	Template string `tfsdk:"template"`
+}
+
+type experimentFaultModel struct {
+	Name     string            `tfsdk:"name"`
+	Template string            `tfsdk:"template"`
+	App      chaosEngineApp    `tfsdk:"app"`
+	Env      map[string]string `tfsdk:"env"`
+}
+
+type experimentProbeModel struct {
+	Name  string       `tfsdk:"name"`
+	Type  types.String `tfsdk:"type"`
+	Mode  string       `tfsdk:"mode"`
+	Fault string       `tfsdk:"fault"`
+}
+
+func newExperimentModel(experiment *experimentManifest) experimentModel {
+	model := experimentModel{
+		Name:     experiment.Name,
+		Kind:     experiment.Kind,
+		Schedule: types.StringNull(),
+		Steps:    make([][]experimentStepModel, 0, len(experiment.Steps)),
+		Faults:   make([]experimentFaultModel, 0, len(experiment.Faults)),
+		Probes:   []experimentProbeModel{},
+	}
+
+	if experiment.Schedule != "" {
+		model.Schedule = types.StringValue(experiment.Schedule)
+	}
+
+	for _, group := range experiment.Steps {
+		steps := make([]experimentStepModel, 0, len(group))
+		for _, step := range group {
+			steps = append(steps, experimentStepModel(step))
+		}
+		model.Steps = append(model.Steps, steps)
+	}
+
+	for _, fault := range experiment.Faults {
+		model.Faults = append(model.Faults, experimentFaultModel{
+			Name:     fault.Name,
+			Template: fault.Template,
+			App: chaosEngineApp{
+				Namespace: fault.App.AppNS,
+				Label:     fault.App.AppLabel,
+				Kind:      fault.App.AppKind,
+			},
+			Env: fault.Env,
+		})
+
+		for _, probe := range fault.Probes {
+			probeType := types.StringNull()
+			if probe.Type != "" {
+				probeType = types.StringValue(probe.Type)
+			}
+			model.Probes = append(model.Probes, experimentProbeModel{
+				Name:  probe.Name,
+				Type:  probeType,
+				Mode:  probe.Mode,
+				Fault: fault.Name,
+			})
+		}
+	}
+
+	return model
+}
+
+// parseExperimentFunction parses experiment manifests, so that configurations
+// can build on their faults and probes.
+type parseExperimentFunction struct{}
+
+func NewParseExperimentFunction() function.Function {
+	return &parseExperimentFunction{}
+}
+
+func (f *parseExperimentFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_experiment"
+}
+
+func (f *parseExperimentFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses a chaos experiment manifest",
+		Description: "Parses the YAML manifest of a chaos experiment, an Argo `Workflow` or a scheduled `CronWorkflow`, " +
+			"offline without contacting the control plane. Fails listing the problems of invalid manifests, " +
+			"as reported by `validate_experiment`.\n\n" +
+			"The result has the `name` and `kind` of the experiment, its cron `schedule` (null unless scheduled), " +
+			"the `steps` of its entrypoint by group of steps running in parallel, each with a `name` and `template`, " +
+			"the `faults` of its ChaosEngines with their `name`, `template`, target `app` and `env`, " +
+			"and their `probes` with the `name`, `type`, `mode` and `fault` of each. " +
+			"The `type` of probes referenced from the control plane by the `probeRef` annotation is null.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "manifest",
+				Description: "YAML manifest of the experiment.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"name":     types.StringType,
+				"kind":     types.StringType,
+				"schedule": types.StringType,
+				"steps":    types.ListType{ElemType: types.ListType{ElemType: experimentStepType}},
+				"faults":   types.ListType{ElemType: experimentFaultType},
+				"probes":   types.ListType{ElemType: experimentProbeType},
+			},
+		},
+	}
+}
+
+func (f *parseExperimentFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var manifest string
+
+	resp.Error = req.Arguments.Get(ctx, &manifest)
+	if resp.Error != nil {
+		return
+	}
+
+	experiment, problems := parseExperimentManifest(manifest)
+	if len(problems) > 0 {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid experiment:\n  - "+strings.Join(problems, "\n  - "))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, newExperimentModel(experiment))
+}
+
+// validateExperimentFunction lists the problems of experiment manifests, for
+// preconditions and checks to report them at plan time.
+type validateExperimentFunction struct{}
+
+func NewValidateExperimentFunction() function.Function {
+	return &validateExperimentFunction{}
+}
+
+func (f *validateExperimentFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_experiment"
+}
+
+func (f *validateExperimentFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validates a chaos experiment manifest",
+		Description: "Validates the YAML manifest of a chaos experiment offline, without contacting the control plane, " +
+			"and returns the list of its problems, empty when the manifest is valid. " +
+			"It checks the structure of the workflow, its cron schedule, the names of its faults and environment variables, " +
+			"the kinds of their target applications and the definitions of their probes, " +
+			"but not whether the faults and probes exist on the control plane.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "manifest",
+				Description: "YAML manifest of the experiment.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *validateExperimentFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var manifest string
+
+	resp.Error = req.Arguments.Get(ctx, &manifest)
+	if resp.Error != nil {
+		return
+	}
+
+	_, problems := parseExperimentManifest(manifest)
+	if problems == nil {
+		problems = []string{}
+	}
+
+	resp.Error = resp.Result.Set(ctx, problems)
+}
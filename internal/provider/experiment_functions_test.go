@@ -0,0 +1,364 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// testExperimentManifest is an experiment as exported from ChaosCenter,
+// running pod-delete against nginx with an inline probe and a probe of the
+// control plane.
+const testExperimentManifest = `kind: Workflow
+apiVersion: argoproj.io/v1alpha1
+metadata:
+  name: nginx-pod-delete
+  namespace: litmus
+spec:
+  entrypoint: argowf-chaos
+  serviceAccountName: argo-chaos
+  templates:
+    - name: argowf-chaos
+      steps:
+        - - name: install-chaos-faults
+            template: install-chaos-faults
+        - - name: pod-delete
+            template: pod-delete
+        - - name: cleanup-chaos-resources
+            template: cleanup-chaos-resources
+    - name: install-chaos-faults
+      inputs:
+        artifacts:
+          - name: pod-delete
+            path: /tmp/pod-delete.yaml
+            raw:
+              data: |
+                apiVersion: litmuschaos.io/v1alpha1
+                kind: ChaosExperiment
+                metadata:
+                  name: pod-delete
+    - name: pod-delete
+      inputs:
+        artifacts:
+          - name: pod-delete
+            path: /tmp/chaosengine-pod-delete.yaml
+            raw:
+              data: |
+                apiVersion: litmuschaos.io/v1alpha1
+                kind: ChaosEngine
+                metadata:
+                  namespace: "{{workflow.parameters.adminModeNamespace}}"
+                  generateName: pod-delete
+                  annotations:
+                    probeRef: '[{"name":"nginx-up","mode":"SOT"}]'
+                spec:
+                  engineState: active
+                  appinfo:
+                    appns: default
+                    applabel: app=nginx
+                    appkind: deployment
+                  chaosServiceAccount: litmus-admin
+                  experiments:
+                    - name: pod-delete
+                      spec:
+                        components:
+                          env:
+                            - name: TOTAL_CHAOS_DURATION
+                              value: "30"
+                            - name: CHAOS_INTERVAL
+                              value: 10
+                        probe:
+                          - name: check-frontend
+                            type: httpProbe
+                            mode: Continuous
+                            runProperties:
+                              probeTimeout: 5s
+                              interval: 2s
+                            httpProbe/inputs:
+                              url: http://nginx.default.svc
+      container:
+        image: litmuschaos/litmus-checker:latest
+        args:
+          - -file=/tmp/chaosengine-pod-delete.yaml
+    - name: cleanup-chaos-resources
+      container:
+        image: litmuschaos/k8s:latest
+`
+
+// testCronExperimentManifest schedules testExperimentManifest.
+func testCronExperimentManifest(schedule string) string {
+	spec := strings.SplitN(testExperimentManifest, "spec:\n", 2)[1]
+
+	return "kind: CronWorkflow\napiVersion: argoproj.io/v1alpha1\nmetadata:\n  name: nginx-pod-delete\n" +
+		"spec:\n  schedule: \"" + schedule + "\"\n  workflowSpec:\n" +
+		strings.ReplaceAll("  "+strings.TrimSuffix(spec, "\n"), "\n", "\n  ") + "\n"
+}
+
+func TestParseExperimentManifest(t *testing.T) {
+	experiment, problems := parseExperimentManifest(testExperimentManifest)
+	if len(problems) > 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+
+	expected := &experimentManifest{
+		Name: "nginx-pod-delete",
+		Kind: "Workflow",
+		Steps: [][]experimentStep{
+			{{Name: "install-chaos-faults", Template: "install-chaos-faults"}},
+			{{Name: "pod-delete", Template: "pod-delete"}},
+			{{Name: "cleanup-chaos-resources", Template: "cleanup-chaos-resources"}},
+		},
+		Faults: []experimentFault{
+			{
+				Name:     "pod-delete",
+				Template: "pod-delete",
+				App:      chaosEngineAppInfo{AppNS: "default", AppLabel: "app=nginx", AppKind: "deployment"},
+				Env:      map[string]string{"TOTAL_CHAOS_DURATION": "30", "CHAOS_INTERVAL": "10"},
+				Probes: []experimentProbe{
+					{Name: "nginx-up", Mode: "SOT"},
+					{Name: "check-frontend", Mode: "Continuous", Type: "httpProbe"},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(experiment, expected) {
+		t.Errorf("expected:\n%+v\ngot:\n%+v", expected, experiment)
+	}
+
+	t.Run("scheduled", func(t *testing.T) {
+		experiment, problems := parseExperimentManifest(testCronExperimentManifest("0 * * * *"))
+		if len(problems) > 0 {
+			t.Fatalf("unexpected problems: %v", problems)
+		}
+		if experiment.Kind != "CronWorkflow" || experiment.Schedule != "0 * * * *" || len(experiment.Faults) != 1 {
+			t.Errorf("unexpected experiment: %+v", experiment)
+		}
+	})
+}
+
+func TestParseExperimentManifestProblems(t *testing.T) {
+	testCases := map[string]struct {
+		manifest string
+		expected []string
+	}{
+		"not yaml": {
+			manifest: "kind: [Workflow",
+			expected: []string{"manifest is not valid YAML: "},
+		},
+		"not a workflow": {
+			manifest: "apiVersion: litmuschaos.io/v1alpha1\nkind: ChaosEngine\nmetadata:\n  name: nginx-chaos\n",
+			expected: []string{
+				`apiVersion: "litmuschaos.io/v1alpha1" must be argoproj.io/v1alpha1`,
+				`kind: "ChaosEngine" must be one of Workflow, CronWorkflow`,
+			},
+		},
+		"invalid name": {
+			manifest: strings.Replace(testExperimentManifest, "name: nginx-pod-delete", "name: Nginx_Pod_Delete", 1),
+			expected: []string{`metadata.name: "Nginx_Pod_Delete" must consist of lower case alphanumeric characters`},
+		},
+		"unknown entrypoint": {
+			manifest: strings.Replace(testExperimentManifest, "entrypoint: argowf-chaos", "entrypoint: chaos", 1),
+			expected: []string{`spec.entrypoint: "chaos" must be the name of a template`},
+		},
+		"unknown step template": {
+			manifest: strings.Replace(testExperimentManifest, "template: cleanup-chaos-resources", "template: cleanup", 1),
+			expected: []string{`spec.templates[0].steps[2][0].template: "cleanup" must be the name of a template`},
+		},
+		"no fault": {
+			manifest: strings.Replace(testExperimentManifest, "kind: ChaosEngine", "kind: ChaosResult", 1),
+			expected: []string{"experiment runs no fault: no template embeds a ChaosEngine"},
+		},
+		"invalid faults": {
+			manifest: strings.NewReplacer(
+				"appkind: deployment", "appkind: pod",
+				"- name: pod-delete\n                      spec:", "- name: Pod-Delete\n                      spec:",
+				"name: CHAOS_INTERVAL", "name: CHAOS INTERVAL",
+				`"mode":"SOT"`, `"mode":"Always"`,
+				"type: httpProbe", "type: grpcProbe",
+			).Replace(testExperimentManifest),
+			expected: []string{
+				`spec.templates[2].inputs.artifacts[0].raw.data.spec.appinfo.appkind: "pod" must be one of deployment, statefulset, daemonset, deploymentconfig, rollout`,
+				`spec.templates[2].inputs.artifacts[0].raw.data.metadata.annotations.probeRef[0].mode: "Always" must be one of SOT, EOT, Edge, Continuous, OnChaos`,
+				`spec.templates[2].inputs.artifacts[0].raw.data.spec.experiments[0].name: "Pod-Delete" must consist of lower case alphanumeric characters`,
+				`spec.templates[2].inputs.artifacts[0].raw.data.spec.experiments[0].spec.components.env: "CHAOS INTERVAL" is not a valid environment variable name`,
+				`spec.templates[2].inputs.artifacts[0].raw.data.spec.experiments[0].spec.probe[0].type: "grpcProbe" must be one of httpProbe, cmdProbe, k8sProbe, promProbe`,
+			},
+		},
+		"invalid probe reference": {
+			manifest: strings.Replace(testExperimentManifest, `'[{"name":"nginx-up","mode":"SOT"}]'`, "nginx-up", 1),
+			expected: []string{"spec.templates[2].inputs.artifacts[0].raw.data.metadata.annotations.probeRef: must be a JSON list of probes: "},
+		},
+		"invalid schedule": {
+			manifest: testCronExperimentManifest("every hour"),
+			expected: []string{`spec.schedule: "every hour" must be a cron schedule of 5 fields`},
+		},
+		"scheduled without spec": {
+			manifest: "apiVersion: argoproj.io/v1alpha1\nkind: CronWorkflow\nmetadata:\n  name: nginx-pod-delete\nspec:\n  schedule: \"@hourly\"\n",
+			expected: []string{"spec.workflowSpec: is required"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, problems := parseExperimentManifest(testCase.manifest)
+			if len(problems) != len(testCase.expected) {
+				t.Fatalf("expected %d problems, got: %q", len(testCase.expected), problems)
+			}
+			for i, expected := range testCase.expected {
+				if !strings.HasPrefix(problems[i], expected) {
+					t.Errorf("expected problem %d to start with %q, got: %q", i, expected, problems[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCronSchedule(t *testing.T) {
+	for _, schedule := range []string{"0 * * * *", "*/5 1-5 * * MON-FRI", "30 2 1,15 * ?", "@daily"} {
+		if problems := validateCronSchedule("schedule", schedule); problems != nil {
+			t.Errorf("expected %q to be valid, got: %v", schedule, problems)
+		}
+	}
+
+	for _, schedule := range []string{"", "* * * *", "* * * * * *", "@every 1h", "0 * * * $"} {
+		if problems := validateCronSchedule("schedule", schedule); problems == nil {
+			t.Errorf("expected %q to be invalid", schedule)
+		}
+	}
+}
+
+// testRunObjectFunction runs f, returning its object result.
+func testRunObjectFunction(f function.Function, arguments []attr.Value) (types.Object, *function.FuncError) {
+	definitionResp := &function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, definitionResp)
+
+	result, err := definitionResp.Definition.Return.NewResultData(context.Background())
+	if err != nil {
+		return types.Object{}, err
+	}
+
+	resp := &function.RunResponse{Result: result}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(arguments)}, resp)
+
+	object, _ := resp.Result.Value().(types.Object)
+
+	return object, resp.Error
+}
+
+func TestParseExperimentFunction(t *testing.T) {
+	result, err := testRunObjectFunction(NewParseExperimentFunction(), []attr.Value{types.StringValue(testExperimentManifest)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var experiment experimentModel
+	if diags := result.As(context.Background(), &experiment, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if !experiment.Schedule.IsNull() {
+		t.Errorf("expected a null schedule, got: %s", experiment.Schedule)
+	}
+	if len(experiment.Steps) != 3 || experiment.Steps[1][0].Template != "pod-delete" {
+		t.Errorf("unexpected steps: %+v", experiment.Steps)
+	}
+	if len(experiment.Faults) != 1 || experiment.Faults[0].App.Kind != "deployment" || experiment.Faults[0].Env["CHAOS_INTERVAL"] != "10" {
+		t.Errorf("unexpected faults: %+v", experiment.Faults)
+	}
+
+	expectedProbes := []experimentProbeModel{
+		{Name: "nginx-up", Type: types.StringNull(), Mode: "SOT", Fault: "pod-delete"},
+		{Name: "check-frontend", Type: types.StringValue("httpProbe"), Mode: "Continuous", Fault: "pod-delete"},
+	}
+	if !reflect.DeepEqual(experiment.Probes, expectedProbes) {
+		t.Errorf("expected probes %+v, got: %+v", expectedProbes, experiment.Probes)
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := testRunObjectFunction(NewParseExperimentFunction(), []attr.Value{types.StringValue(testCronExperimentManifest("hourly"))})
+
+		expected := "Invalid experiment:\n  - spec.schedule: \"hourly\" must be a cron schedule"
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected an error containing %q, got: %v", expected, err)
+		}
+	})
+}
+
+func TestValidateExperimentFunction(t *testing.T) {
+	testCases := map[string]struct {
+		manifest string
+		expected []string
+	}{
+		"valid": {
+			manifest: testExperimentManifest,
+			expected: []string{},
+		},
+		"invalid": {
+			manifest: strings.Replace(testExperimentManifest, "appkind: deployment", "appkind: pod", 1),
+			expected: []string{
+				`spec.templates[2].inputs.artifacts[0].raw.data.spec.appinfo.appkind: "pod" must be one of deployment, statefulset, daemonset, deploymentconfig, rollout`,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			f := NewValidateExperimentFunction()
+
+			resp := &function.RunResponse{Result: function.NewResultData(types.ListUnknown(types.StringType))}
+			f.Run(context.Background(), function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(testCase.manifest)}),
+			}, resp)
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %v", resp.Error)
+			}
+
+			var problems []string
+			if diags := resp.Result.Value().(types.List).ElementsAs(context.Background(), &problems, false); diags.HasError() { //nolint:forcetypeassert // list return
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !reflect.DeepEqual(problems, testCase.expected) {
+				t.Errorf("expected %q, got: %q", testCase.expected, problems)
+			}
+		})
+	}
+}
+
+func TestAccExperimentFunctions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  manifest = <<-EOT
+` + testExperimentManifest + `EOT
+}
+
+output "faults" {
+  value = join(",", provider::litmus-chaos::parse_experiment(local.manifest).faults[*].name)
+}
+
+output "problems" {
+  value = length(provider::litmus-chaos::validate_experiment(local.manifest))
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("faults", "pod-delete"),
+					resource.TestCheckOutput("problems", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,290 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Chaos experiments are Argo Workflows, or CronWorkflows for scheduled ones,
+// whose steps apply ChaosEngines embedded as raw artifacts of their templates.
+// parseExperimentManifest is the single place they are parsed and validated,
+// shared by the parse_experiment and validate_experiment functions and meant
+// for the config validation of an experiment resource.
+
+const argoAPIVersion = "argoproj.io/v1alpha1"
+
+// cronField is a field of a cron schedule, such as "*/5" or "1-5".
+var cronField = regexp.MustCompile(`^[0-9A-Za-z*?,/\-]+$`)
+
+// cronDescriptors are the predefined schedules of Argo CronWorkflows.
+var cronDescriptors = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+// experimentManifest is what is known of an experiment without contacting the
+// control plane.
+type experimentManifest struct {
+	Name     string
+	Kind     string
+	Schedule string
+
+	// Steps are the steps of the entrypoint, by group of steps running in
+	// parallel.
+	Steps [][]experimentStep
+
+	Faults []experimentFault
+}
+
+type experimentStep struct {
+	Name     string
+	Template string
+}
+
+type experimentFault struct {
+	Name     string
+	Template string
+	App      chaosEngineAppInfo
+	Env      map[string]string
+	Probes   []experimentProbe
+}
+
+type experimentProbe struct {
+	Name string
+	Mode string
+
+	// Type is empty for probes referenced from the probes of the control
+	// plane rather than defined inline.
+	Type string
+}
+
+// argoWorkflow holds the fields of Workflows and CronWorkflows experiments
+// are made of.
+type argoWorkflow struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name         string `yaml:"name"`
+		GenerateName string `yaml:"generateName"`
+	} `yaml:"metadata"`
+	Spec struct {
+		argoWorkflowSpec `yaml:",inline"`
+
+		// Schedule and WorkflowSpec are set on CronWorkflows only.
+		Schedule     string            `yaml:"schedule"`
+		WorkflowSpec *argoWorkflowSpec `yaml:"workflowSpec"`
+	} `yaml:"spec"`
+}
+
+type argoWorkflowSpec struct {
+	Entrypoint string         `yaml:"entrypoint"`
+	Templates  []argoTemplate `yaml:"templates"`
+}
+
+type argoTemplate struct {
+	Name   string           `yaml:"name"`
+	Steps  [][]argoStep     `yaml:"steps"`
+	Inputs argoTemplateArgs `yaml:"inputs"`
+}
+
+type argoStep struct {
+	Name     string `yaml:"name"`
+	Template string `yaml:"template"`
+}
+
+type argoTemplateArgs struct {
+	Artifacts []struct {
+		Name string `yaml:"name"`
+		Raw  struct {
+			Data string `yaml:"data"`
+		} `yaml:"raw"`
+	} `yaml:"artifacts"`
+}
+
+// embeddedChaosEngine holds the fields of the ChaosEngines of experiments.
+type embeddedChaosEngine struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Annotations map[string]string `yaml:"annotations"`
+	} `yaml:"metadata"`
+	Spec struct {
+		AppInfo     chaosEngineAppInfo `yaml:"appinfo"`
+		Experiments []struct {
+			Name string `yaml:"name"`
+			Spec struct {
+				Components struct {
+					Env []chaosEngineEnv `yaml:"env"`
+				} `yaml:"components"`
+				Probe []any `yaml:"probe"`
+			} `yaml:"spec"`
+		} `yaml:"experiments"`
+	} `yaml:"spec"`
+}
+
+// parseExperimentManifest parses an experiment manifest and returns the
+// problems found in it. The manifest is nil when it cannot be parsed at all.
+func parseExperimentManifest(manifest string) (*experimentManifest, []string) {
+	var workflow argoWorkflow
+	if err := yaml.Unmarshal([]byte(manifest), &workflow); err != nil {
+		return nil, []string{"manifest is not valid YAML: " + err.Error()}
+	}
+
+	var problems []string
+
+	if workflow.APIVersion != argoAPIVersion {
+		problems = append(problems, fmt.Sprintf("apiVersion: %q must be %s", workflow.APIVersion, argoAPIVersion))
+	}
+
+	experiment := &experimentManifest{
+		Name:     workflow.Metadata.Name,
+		Kind:     workflow.Kind,
+		Schedule: workflow.Spec.Schedule,
+		Steps:    [][]experimentStep{},
+		Faults:   []experimentFault{},
+	}
+
+	if experiment.Name == "" {
+		experiment.Name = workflow.Metadata.GenerateName
+	}
+	if experiment.Name == "" {
+		problems = append(problems, "metadata.name: is required")
+	} else {
+		problems = append(problems, validateDNSSubdomain("metadata.name", strings.TrimSuffix(experiment.Name, "-"))...)
+	}
+
+	spec, specField := workflow.Spec.argoWorkflowSpec, "spec"
+	switch workflow.Kind {
+	case "Workflow":
+	case "CronWorkflow":
+		problems = append(problems, validateCronSchedule("spec.schedule", workflow.Spec.Schedule)...)
+		if workflow.Spec.WorkflowSpec == nil {
+			return experiment, append(problems, "spec.workflowSpec: is required")
+		}
+		spec, specField = *workflow.Spec.WorkflowSpec, "spec.workflowSpec"
+	default:
+		return experiment, append(problems, fmt.Sprintf("kind: %q must be one of Workflow, CronWorkflow", workflow.Kind))
+	}
+
+	templates := make(map[string]int, len(spec.Templates))
+	for i, template := range spec.Templates {
+		templates[template.Name] = i
+	}
+
+	var entrypoint argoTemplate
+	entrypointIndex, ok := templates[spec.Entrypoint]
+	if ok {
+		entrypoint = spec.Templates[entrypointIndex]
+	} else {
+		problems = append(problems, fmt.Sprintf("%s.entrypoint: %q must be the name of a template", specField, spec.Entrypoint))
+	}
+
+	for i, group := range entrypoint.Steps {
+		steps := make([]experimentStep, 0, len(group))
+		for j, step := range group {
+			if _, ok := templates[step.Template]; !ok {
+				problems = append(problems, fmt.Sprintf("%s.templates[%d].steps[%d][%d].template: %q must be the name of a template", specField, entrypointIndex, i, j, step.Template))
+			}
+			steps = append(steps, experimentStep{Name: step.Name, Template: step.Template})
+		}
+		experiment.Steps = append(experiment.Steps, steps)
+	}
+
+	for i, template := range spec.Templates {
+		for j, artifact := range template.Inputs.Artifacts {
+			field := fmt.Sprintf("%s.templates[%d].inputs.artifacts[%d].raw.data", specField, i, j)
+
+			faults, faultProblems := parseEmbeddedChaosEngine(field, template.Name, artifact.Raw.Data)
+			experiment.Faults = append(experiment.Faults, faults...)
+			problems = append(problems, faultProblems...)
+		}
+	}
+
+	if len(experiment.Faults) == 0 {
+		problems = append(problems, "experiment runs no fault: no template embeds a ChaosEngine")
+	}
+
+	return experiment, problems
+}
+
+// parseEmbeddedChaosEngine returns the faults of an artifact of template,
+// none when the artifact is not a ChaosEngine, e.g. a ChaosExperiment.
+func parseEmbeddedChaosEngine(field string, template string, data string) ([]experimentFault, []string) {
+	var engine embeddedChaosEngine
+	if err := yaml.Unmarshal([]byte(data), &engine); err != nil || engine.Kind != "ChaosEngine" {
+		return nil, nil
+	}
+
+	var problems []string
+
+	if app := engine.Spec.AppInfo; app.AppKind != "" {
+		problems = append(problems, validateOneOf(field+".spec.appinfo.appkind", app.AppKind, appKinds)...)
+	}
+
+	if len(engine.Spec.Experiments) == 0 {
+		problems = append(problems, field+".spec.experiments: the ChaosEngine runs no fault")
+	}
+
+	// Probes defined in the control plane are referenced by annotation
+	var probeRefs []experimentProbe
+	if annotation, ok := engine.Metadata.Annotations["probeRef"]; ok {
+		if err := json.Unmarshal([]byte(annotation), &probeRefs); err != nil {
+			problems = append(problems, field+".metadata.annotations.probeRef: must be a JSON list of probes: "+err.Error())
+		}
+		for i, probe := range probeRefs {
+			problems = append(problems, validateOneOf(fmt.Sprintf("%s.metadata.annotations.probeRef[%d].mode", field, i), probe.Mode, probeModes)...)
+		}
+	}
+
+	faults := make([]experimentFault, 0, len(engine.Spec.Experiments))
+	for i, spec := range engine.Spec.Experiments {
+		experimentField := fmt.Sprintf("%s.spec.experiments[%d]", field, i)
+		problems = append(problems, validateDNSLabel(experimentField+".name", spec.Name)...)
+
+		fault := experimentFault{
+			Name:     spec.Name,
+			Template: template,
+			App:      engine.Spec.AppInfo,
+			Env:      map[string]string{},
+			Probes:   append([]experimentProbe{}, probeRefs...),
+		}
+
+		for _, env := range spec.Spec.Components.Env {
+			fault.Env[env.Name] = env.Value
+		}
+		problems = append(problems, validateEnvNames(experimentField+".spec.components.env", fault.Env)...)
+
+		problems = append(problems, validateProbes(experimentField+".spec.probe", spec.Spec.Probe)...)
+		for _, item := range spec.Spec.Probe {
+			probe, _ := item.(map[string]any)
+			name, _ := probe["name"].(string)
+			probeType, _ := probe["type"].(string)
+			mode, _ := probe["mode"].(string)
+			fault.Probes = append(fault.Probes, experimentProbe{Name: name, Type: probeType, Mode: mode})
+		}
+
+		faults = append(faults, fault)
+	}
+
+	return faults, problems
+}
+
+func validateCronSchedule(field string, schedule string) []string {
+	for _, descriptor := range cronDescriptors {
+		if schedule == descriptor {
+			return nil
+		}
+	}
+
+	fields := strings.Fields(schedule)
+	valid := len(fields) == 5
+	for _, f := range fields {
+		valid = valid && cronField.MatchString(f)
+	}
+
+	if !valid {
+		return []string{fmt.Sprintf("%s: %q must be a cron schedule of 5 fields, such as \"0 * * * *\", or one of %s", field, schedule, strings.Join(cronDescriptors, ", "))}
+	}
+
+	return nil
+}
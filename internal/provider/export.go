@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/williamokano/litmus-chaos-thin-client/pkg/entities"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ExportOptions selects what Export writes.
+type ExportOptions struct {
+	// ProjectID is the project to export, the provider project_id when empty.
+	// Every project the user can see is exported when neither is set.
+	ProjectID string
+
+	// Dir is the directory the configuration files are written to.
+	Dir string
+}
+
+// Export writes the configuration of existing control plane objects as
+// Terraform resources, along with the import blocks bringing them under
+// Terraform. It connects like the provider does, configured from the
+// LITMUS_CHAOS_* environment variables, and returns the files written.
+//
+// Only projects are exported so far.
+func Export(ctx context.Context, version string, options ExportOptions) ([]string, error) {
+	data, err := configureFromEnvironment(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	projectID := options.ProjectID
+	if projectID == "" {
+		projectID = data.defaultProjectID
+	}
+
+	var projects []entities.Project
+	if projectID != "" {
+		project, err := data.client.GetProject(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, *project)
+	} else {
+		projects, err = data.client.ListProjects(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	path := filepath.Join(options.Dir, "projects.tf")
+	if err := writeConfiguration(path, exportProjects(projects)); err != nil {
+		return nil, err
+	}
+
+	return []string{path}, nil
+}
+
+// exportProjects returns the configuration of projects, sorted by name.
+func exportProjects(projects []entities.Project) *hclwrite.File {
+	sort.SliceStable(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+
+	file := hclwrite.NewEmptyFile()
+	body := file.Body()
+	labels := map[string]bool{}
+
+	for i, project := range projects {
+		if i > 0 {
+			body.AppendNewline()
+		}
+
+		label := uniqueLabel(resourceLabel(project.Name, "project"), labels)
+
+		resource := body.AppendNewBlock("resource", []string{"litmus-chaos_project", label})
+		resource.Body().SetAttributeValue("name", cty.StringVal(project.Name))
+
+		body.AppendNewline()
+		appendImportBlock(body, "litmus-chaos_project", label, project.ID)
+	}
+
+	return file
+}
+
+func appendImportBlock(body *hclwrite.Body, resourceType string, label string, id string) {
+	block := body.AppendNewBlock("import", nil)
+	block.Body().SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: label},
+	})
+	block.Body().SetAttributeValue("id", cty.StringVal(id))
+}
+
+// resourceLabel turns the name of an object into a resource label, e.g.
+// "Main Project" into "main_project". Names without any usable character
+// fall back to kind.
+func resourceLabel(name string, kind string) string {
+	var b strings.Builder
+	underscore := false
+
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if underscore && b.Len() > 0 {
+				b.WriteRune('_')
+			}
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		underscore = true
+	}
+
+	label := b.String()
+	switch {
+	case label == "":
+		return kind
+	case unicode.IsDigit(rune(label[0])):
+		return kind + "_" + label
+	}
+
+	return label
+}
+
+// uniqueLabel returns label, suffixed with a number when it is already in
+// labels, and records it.
+func uniqueLabel(label string, labels map[string]bool) string {
+	unique := label
+	for i := 2; labels[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", label, i)
+	}
+	labels[unique] = true
+
+	return unique
+}
+
+// writeConfiguration writes file to path, refusing to overwrite an existing
+// file.
+func writeConfiguration(path string, file *hclwrite.File) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("%s already exists, export to another directory or remove it", path)
+		}
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	if _, err := file.WriteTo(out); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return out.Close()
+}
+
+// configureFromEnvironment configures the provider outside of Terraform, with
+// an empty configuration so that every setting comes from the environment.
+func configureFromEnvironment(ctx context.Context, version string) (*providerData, error) {
+	p := New(version)()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		return nil, errors.New("expected the provider schema to be an object")
+	}
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objectType, attributes),
+		},
+	}, resp)
+
+	if resp.Diagnostics.HasError() {
+		errs := make([]error, 0, len(resp.Diagnostics.Errors()))
+		for _, d := range resp.Diagnostics.Errors() {
+			errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+		}
+		return nil, errors.Join(errs...)
+	}
+
+	data, ok := resp.ResourceData.(*providerData)
+	if !ok {
+		return nil, fmt.Errorf("expected *provider.providerData, got: %T", resp.ResourceData)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/williamokano/terraform-provider-litmus-chaos/internal/fakelitmus"
+)
+
+func TestExport(t *testing.T) {
+	server := fakelitmus.NewServer()
+	defer server.Close()
+
+	t.Setenv("LITMUS_CHAOS_HOST", server.URL)
+	t.Setenv("LITMUS_CHAOS_TOKEN", "")
+	t.Setenv("LITMUS_CHAOS_USERNAME", fakelitmus.AdminUsername)
+	t.Setenv("LITMUS_CHAOS_PASSWORD", fakelitmus.AdminPassword)
+	t.Setenv("LITMUS_CHAOS_PROJECT_ID", "")
+
+	main := server.AddProject("Main Project")
+	other := server.AddProject("main-project")
+
+	dir := t.TempDir()
+	files, err := Export(context.Background(), "test", ExportOptions{Dir: dir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(files) != 1 || files[0] != filepath.Join(dir, "projects.tf") {
+		t.Fatalf("expected projects.tf to be written, got %q", files)
+	}
+
+	actual, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `resource "litmus-chaos_project" "main_project" {
+  name = "Main Project"
+}
+
+import {
+  to = litmus-chaos_project.main_project
+  id = "` + main.ID + `"
+}
+
+resource "litmus-chaos_project" "main_project_2" {
+  name = "main-project"
+}
+
+import {
+  to = litmus-chaos_project.main_project_2
+  id = "` + other.ID + `"
+}
+`
+	if string(actual) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+
+	if _, err := Export(context.Background(), "test", ExportOptions{Dir: dir}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected existing files not to be overwritten, got: %v", err)
+	}
+
+	t.Run("project", func(t *testing.T) {
+		dir := t.TempDir()
+		files, err := Export(context.Background(), "test", ExportOptions{Dir: dir, ProjectID: other.ID})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		actual, err := os.ReadFile(files[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if strings.Contains(string(actual), main.ID) || !strings.Contains(string(actual), other.ID) {
+			t.Errorf("expected only project %s to be exported, got:\n%s", other.ID, actual)
+		}
+	})
+}
+
+func TestResourceLabel(t *testing.T) {
+	testCases := map[string]string{
+		"Main Project":    "main_project",
+		"  staging--eu ":  "staging_eu",
+		"2024 game days":  "project_2024_game_days",
+		"équipe":          "quipe",
+		"!!!":             "project",
+		"already_a_label": "already_a_label",
+	}
+
+	for name, expected := range testCases {
+		if actual := resourceLabel(name, "project"); actual != expected {
+			t.Errorf("expected %q to become %q, got %q", name, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/williamokano/terraform-provider-litmus-chaos/internal/fakelitmus"
+)
+
+// testFaultyClient configures the provider against server with fast retries
+// and a short request timeout.
+func testFaultyClient(t *testing.T, server *fakelitmus.Server) litmusAPI {
+	t.Helper()
+
+	resp := testConfigureProvider(context.Background(), t, map[string]tftypes.Value{
+		"host":            tftypes.NewValue(tftypes.String, server.URL),
+		"username":        tftypes.NewValue(tftypes.String, fakelitmus.AdminUsername),
+		"password":        tftypes.NewValue(tftypes.String, fakelitmus.AdminPassword),
+		"retry_wait_min":  tftypes.NewValue(tftypes.String, "1ms"),
+		"retry_wait_max":  tftypes.NewValue(tftypes.String, "5ms"),
+		"request_timeout": tftypes.NewValue(tftypes.String, "200ms"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	return resp.ResourceData.(*providerData).client //nolint:forcetypeassert // checked by Configure
+}
+
+func TestFaults(t *testing.T) {
+	testCases := map[string]struct {
+		serverVersion    string
+		operation        string
+		fault            fakelitmus.Fault
+		expectedError    string
+		expectedRequests int
+		notFound         bool
+	}{
+		"transient 503 is retried": {
+			operation:        "get_project",
+			fault:            fakelitmus.Fault{StatusCode: http.StatusServiceUnavailable, Times: 2},
+			expectedRequests: 3,
+		},
+		"persistent 503 gives up": {
+			operation:        "get_project",
+			fault:            fakelitmus.Fault{StatusCode: http.StatusServiceUnavailable, Times: 10},
+			expectedError:    "got 503",
+			expectedRequests: 4,
+		},
+		"500 is not retried": {
+			operation:        "get_project",
+			fault:            fakelitmus.Fault{StatusCode: http.StatusInternalServerError},
+			expectedError:    "got 500",
+			expectedRequests: 1,
+		},
+		"graphql errors": {
+			serverVersion:    "2.14.0",
+			operation:        "getProject",
+			fault:            fakelitmus.Fault{GraphQLErrors: []string{"database unavailable"}},
+			expectedError:    "graphql errors: database unavailable",
+			expectedRequests: 1,
+		},
+		"graphql missing document": {
+			serverVersion:    "2.14.0",
+			operation:        "getProject",
+			fault:            fakelitmus.Fault{GraphQLErrors: []string{"mongo: no documents in result"}},
+			expectedError:    "graphql errors: mongo: no documents in result",
+			expectedRequests: 1,
+			notFound:         true,
+		},
+		"graphql unrelated not found": {
+			serverVersion:    "2.14.0",
+			operation:        "getProject",
+			fault:            fakelitmus.Fault{GraphQLErrors: []string{"user not found"}},
+			expectedError:    "graphql errors: user not found",
+			expectedRequests: 1,
+		},
+		"slow response within request_timeout": {
+			operation:        "get_project",
+			fault:            fakelitmus.Fault{Delay: 50 * time.Millisecond},
+			expectedRequests: 1,
+		},
+		"slow response beyond request_timeout is retried": {
+			operation:        "get_project",
+			fault:            fakelitmus.Fault{Delay: time.Second},
+			expectedRequests: 2,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			serverVersion := testCase.serverVersion
+			if serverVersion == "" {
+				serverVersion = fakelitmus.DefaultVersion
+			}
+
+			server := fakelitmus.NewServer(fakelitmus.WithVersion(serverVersion))
+			defer server.Close()
+
+			api := testFaultyClient(t, server)
+			project := server.AddProject("tf-acc-faults")
+
+			server.InjectFault(testCase.operation, testCase.fault)
+			_, err := api.GetProject(context.Background(), project.ID)
+
+			switch {
+			case testCase.expectedError == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case testCase.expectedError != "" && (err == nil || !strings.Contains(err.Error(), testCase.expectedError)):
+				t.Errorf("expected error containing %q, got %v", testCase.expectedError, err)
+			}
+			if errors.Is(err, errNotFound) != testCase.notFound {
+				t.Errorf("expected not found to be %t, got error: %v", testCase.notFound, err)
+			}
+
+			if actual := server.Requests(testCase.operation); actual != testCase.expectedRequests {
+				t.Errorf("expected %d requests, got %d", testCase.expectedRequests, actual)
+			}
+		})
+	}
+}
+
+func TestFaultExpiredTokens(t *testing.T) {
+	server := fakelitmus.NewServer()
+	defer server.Close()
+
+	api := testFaultyClient(t, server)
+	project := server.AddProject("tf-acc-faults")
+
+	server.ExpireTokens()
+	if _, err := api.GetProject(context.Background(), project.ID); err != nil {
+		t.Fatalf("expected the token to be refreshed, got: %v", err)
+	}
+
+	if actual := server.Requests("login"); actual != 2 {
+		t.Errorf("expected a second login, got %d logins", actual)
+	}
+}
+
+func TestFaultOutOfBandDeletion(t *testing.T) {
+	for _, serverVersion := range []string{"2.14.0", "3.9.0"} {
+		t.Run(serverVersion, func(t *testing.T) {
+			server := fakelitmus.NewServer(fakelitmus.WithVersion(serverVersion))
+			defer server.Close()
+
+			api := testFaultyClient(t, server)
+			project := server.AddProject("tf-acc-faults")
+			server.DeleteProject(project.ID)
+
+			if _, err := api.GetProject(context.Background(), project.ID); !errors.Is(err, errNotFound) {
+				t.Errorf("expected a not found error, got: %v", err)
+			}
+		})
+	}
+}
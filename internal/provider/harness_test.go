@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/williamokano/litmus-chaos-thin-client/pkg/entities"
+	litmusgraphql "github.com/williamokano/litmus-chaos-thin-client/pkg/graphql"
+)
+
+// mockAPI is a litmusAPI whose methods are stubbed by its fields, so resources
+// and data sources can be tested without a control plane. Calling a method
+// which is not stubbed returns an error.
+type mockAPI struct {
+	createProject     func(ctx context.Context, projectName string) (*entities.Project, error)
+	getProject        func(ctx context.Context, projectID string) (*entities.Project, error)
+	listProjects      func(ctx context.Context) ([]entities.Project, error)
+	updateProjectName func(ctx context.Context, projectID string, projectName string) (*entities.Project, error)
+
+	createEnvironment func(ctx context.Context, projectID string, request litmusgraphql.CreateEnvironmentRequest) (*entities.Environment, error)
+	getEnvironment    func(ctx context.Context, projectID string, environmentID string) (*entities.Environment, error)
+	updateEnvironment func(ctx context.Context, projectID string, request litmusgraphql.UpdateEnvironmentRequest) (*entities.Environment, error)
+	deleteEnvironment func(ctx context.Context, projectID string, environmentID string) error
+
+	findUserByUsername func(ctx context.Context, username string) (*entities.User, error)
+	updatePassword     func(ctx context.Context, username string, oldPassword string, newPassword string) error
+	resetPassword      func(ctx context.Context, username string, newPassword string) error
+
+	currentUser func(ctx context.Context) (*tokenClaims, error)
+	issueToken  func(ctx context.Context) (string, error)
+	serverInfo  func(ctx context.Context) (*serverInfo, error)
+}
+
+var _ litmusAPI = &mockAPI{}
+
+func errUnexpectedCall(method string) error {
+	return errors.New("unexpected call to " + method)
+}
+
+func (m *mockAPI) CreateProject(ctx context.Context, projectName string) (*entities.Project, error) {
+	if m.createProject == nil {
+		return nil, errUnexpectedCall("CreateProject")
+	}
+	return m.createProject(ctx, projectName)
+}
+
+func (m *mockAPI) GetProject(ctx context.Context, projectID string) (*entities.Project, error) {
+	if m.getProject == nil {
+		return nil, errUnexpectedCall("GetProject")
+	}
+	return m.getProject(ctx, projectID)
+}
+
+func (m *mockAPI) ListProjects(ctx context.Context) ([]entities.Project, error) {
+	if m.listProjects == nil {
+		return nil, errUnexpectedCall("ListProjects")
+	}
+	return m.listProjects(ctx)
+}
+
+func (m *mockAPI) UpdateProjectName(ctx context.Context, projectID string, projectName string) (*entities.Project, error) {
+	if m.updateProjectName == nil {
+		return nil, errUnexpectedCall("UpdateProjectName")
+	}
+	return m.updateProjectName(ctx, projectID, projectName)
+}
+
+func (m *mockAPI) CreateEnvironment(ctx context.Context, projectID string, request litmusgraphql.CreateEnvironmentRequest) (*entities.Environment, error) {
+	if m.createEnvironment == nil {
+		return nil, errUnexpectedCall("CreateEnvironment")
+	}
+	return m.createEnvironment(ctx, projectID, request)
+}
+
+func (m *mockAPI) GetEnvironment(ctx context.Context, projectID string, environmentID string) (*entities.Environment, error) {
+	if m.getEnvironment == nil {
+		return nil, errUnexpectedCall("GetEnvironment")
+	}
+	return m.getEnvironment(ctx, projectID, environmentID)
+}
+
+func (m *mockAPI) UpdateEnvironment(ctx context.Context, projectID string, request litmusgraphql.UpdateEnvironmentRequest) (*entities.Environment, error) {
+	if m.updateEnvironment == nil {
+		return nil, errUnexpectedCall("UpdateEnvironment")
+	}
+	return m.updateEnvironment(ctx, projectID, request)
+}
+
+func (m *mockAPI) DeleteEnvironment(ctx context.Context, projectID string, environmentID string) error {
+	if m.deleteEnvironment == nil {
+		return errUnexpectedCall("DeleteEnvironment")
+	}
+	return m.deleteEnvironment(ctx, projectID, environmentID)
+}
+
+func (m *mockAPI) FindUserByUsername(ctx context.Context, username string) (*entities.User, error) {
+	if m.findUserByUsername == nil {
+		return nil, errUnexpectedCall("FindUserByUsername")
+	}
+	return m.findUserByUsername(ctx, username)
+}
+
+func (m *mockAPI) UpdatePassword(ctx context.Context, username string, oldPassword string, newPassword string) error {
+	if m.updatePassword == nil {
+		return errUnexpectedCall("UpdatePassword")
+	}
+	return m.updatePassword(ctx, username, oldPassword, newPassword)
+}
+
+func (m *mockAPI) ResetPassword(ctx context.Context, username string, newPassword string) error {
+	if m.resetPassword == nil {
+		return errUnexpectedCall("ResetPassword")
+	}
+	return m.resetPassword(ctx, username, newPassword)
+}
+
+func (m *mockAPI) CurrentUser(ctx context.Context) (*tokenClaims, error) {
+	if m.currentUser == nil {
+		return nil, errUnexpectedCall("CurrentUser")
+	}
+	return m.currentUser(ctx)
+}
+
+func (m *mockAPI) IssueToken(ctx context.Context) (string, error) {
+	if m.issueToken == nil {
+		return "", errUnexpectedCall("IssueToken")
+	}
+	return m.issueToken(ctx)
+}
+
+func (m *mockAPI) ServerInfo(ctx context.Context) (*serverInfo, error) {
+	if m.serverInfo == nil {
+		return nil, errUnexpectedCall("ServerInfo")
+	}
+	return m.serverInfo(ctx)
+}
+
+// testObject returns an object of objectType, which must be the type of a
+// schema, with the given attributes and every other attribute null.
+func testObject(t *testing.T, objectType tftypes.Type, values map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	object, ok := objectType.(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected an object type, got %s", objectType)
+	}
+
+	attributes := make(map[string]tftypes.Value, len(object.AttributeTypes))
+	for name, attributeType := range object.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range values {
+		if _, ok := object.AttributeTypes[name]; !ok {
+			t.Fatalf("unknown attribute %q", name)
+		}
+		attributes[name] = value
+	}
+
+	return tftypes.NewValue(object, attributes)
+}
+
+// testCheckDiagnostics fails the test unless diags holds an error whose
+// summary or detail contains expectedError, or no error when it is empty.
+func testCheckDiagnostics(t *testing.T, diags diag.Diagnostics, expectedError string) {
+	t.Helper()
+
+	if expectedError == "" {
+		if diags.HasError() {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+		return
+	}
+
+	for _, d := range diags.Errors() {
+		if strings.Contains(d.Summary(), expectedError) || strings.Contains(d.Detail(), expectedError) {
+			return
+		}
+	}
+	t.Errorf("expected an error containing %q, got: %v", expectedError, diags)
+}
+
+// testCheckState fails the test unless the string attributes of state have
+// the expected values. A nil expected means the state must be removed.
+func testCheckState(t *testing.T, state tfsdk.State, expected map[string]string) {
+	t.Helper()
+
+	if expected == nil {
+		if !state.Raw.IsNull() {
+			t.Errorf("expected the state to be removed, got %s", state.Raw)
+		}
+		return
+	}
+
+	for name, expectedValue := range expected {
+		var actual types.String
+		if diags := state.GetAttribute(context.Background(), path.Root(name), &actual); diags.HasError() {
+			t.Errorf("failed to get %s: %v", name, diags)
+			continue
+		}
+
+		if actual.ValueString() != expectedValue {
+			t.Errorf("expected %s to be %q, got %s", name, expectedValue, actual)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// The validation of chaos manifests is shared by the provider functions
+// rendering and checking them. It runs offline, so it only checks what can
+// be known without a cluster: names, enumerations and required fields.
+
+var (
+	// dnsLabel is a Kubernetes DNS-1123 label, as used for namespaces and
+	// fault names.
+	dnsLabel = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+	// dnsSubdomain is a Kubernetes DNS-1123 subdomain, as used for object
+	// names.
+	dnsSubdomain = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+	// envVarName is a name of environment variable, as accepted by Kubernetes
+	// containers.
+	envVarName = regexp.MustCompile(`^[-._a-zA-Z][-._a-zA-Z0-9]*$`)
+)
+
+// appKinds are the kinds of workloads faults can target.
+var appKinds = []string{"deployment", "statefulset", "daemonset", "deploymentconfig", "rollout"}
+
+// probeTypes are the types of resilience probes, each configured by the
+// "<type>/inputs" field of the probe.
+var probeTypes = []string{"httpProbe", "cmdProbe", "k8sProbe", "promProbe"}
+
+// probeModes are the modes of resilience probes.
+var probeModes = []string{"SOT", "EOT", "Edge", "Continuous", "OnChaos"}
+
+func validateDNSLabel(field string, value string) []string {
+	if len(value) > 63 || !dnsLabel.MatchString(value) {
+		return []string{fmt.Sprintf("%s: %q must consist of lower case alphanumeric characters or '-', start and end with an alphanumeric character, and be at most 63 characters", field, value)}
+	}
+
+	return nil
+}
+
+func validateDNSSubdomain(field string, value string) []string {
+	if len(value) > 253 || !dnsSubdomain.MatchString(value) {
+		return []string{fmt.Sprintf("%s: %q must consist of lower case alphanumeric characters, '-' or '.', start and end with an alphanumeric character, and be at most 253 characters", field, value)}
+	}
+
+	return nil
+}
+
+func validateOneOf(field string, value string, allowed []string) []string {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+
+	return []string{fmt.Sprintf("%s: %q must be one of %s", field, value, strings.Join(allowed, ", "))}
+}
+
+func validateEnvNames(field string, env map[string]string) []string {
+	var problems []string
+
+	for _, name := range sortedKeys(env) {
+		if !envVarName.MatchString(name) {
+			problems = append(problems, fmt.Sprintf("%s: %q is not a valid environment variable name", field, name))
+		}
+	}
+
+	return problems
+}
+
+// validateProbes checks resilience probe definitions, as found under the
+// "probe" field of the faults of ChaosEngines and experiments.
+func validateProbes(field string, probes []any) []string {
+	var problems []string
+	names := map[string]bool{}
+
+	for i, item := range probes {
+		probeField := fmt.Sprintf("%s[%d]", field, i)
+
+		probe, ok := item.(map[string]any)
+		if !ok {
+			problems = append(problems, probeField+": must be an object")
+			continue
+		}
+
+		name, _ := probe["name"].(string)
+		switch {
+		case name == "":
+			problems = append(problems, probeField+".name: is required")
+		case names[name]:
+			problems = append(problems, fmt.Sprintf("%s.name: %q is used by another probe", probeField, name))
+		}
+		names[name] = true
+
+		probeType, _ := probe["type"].(string)
+		if typeProblems := validateOneOf(probeField+".type", probeType, probeTypes); typeProblems != nil {
+			problems = append(problems, typeProblems...)
+		} else if _, ok := probe[probeType+"/inputs"].(map[string]any); !ok {
+			problems = append(problems, fmt.Sprintf("%s: %s/inputs is required", probeField, probeType))
+		}
+
+		mode, _ := probe["mode"].(string)
+		problems = append(problems, validateOneOf(probeField+".mode", mode, probeModes)...)
+
+		runProperties, ok := probe["runProperties"].(map[string]any)
+		if !ok {
+			problems = append(problems, probeField+".runProperties: is required")
+			continue
+		}
+		for _, property := range []string{"probeTimeout", "interval"} {
+			if _, ok := runProperties[property]; !ok {
+				problems = append(problems, fmt.Sprintf("%s.runProperties.%s: is required", probeField, property))
+			}
+		}
+	}
+
+	return problems
+}
+
+// goValue converts a Terraform value into the plain Go value of its JSON
+// representation, so that it can be validated and marshaled into manifests.
+func goValue(value attr.Value) (any, error) {
+	if value == nil || value.IsNull() {
+		return nil, nil
+	}
+	if value.IsUnknown() {
+		return nil, fmt.Errorf("value is unknown")
+	}
+
+	switch v := value.(type) {
+	case basetypes.DynamicValue:
+		return goValue(v.UnderlyingValue())
+	case basetypes.StringValue:
+		return v.ValueString(), nil
+	case basetypes.BoolValue:
+		return v.ValueBool(), nil
+	case basetypes.NumberValue:
+		return goNumber(v.ValueBigFloat()), nil
+	case basetypes.Int64Value:
+		return v.ValueInt64(), nil
+	case basetypes.Float64Value:
+		return v.ValueFloat64(), nil
+	case basetypes.ListValue:
+		return goList(v.Elements())
+	case basetypes.SetValue:
+		return goList(v.Elements())
+	case basetypes.TupleValue:
+		return goList(v.Elements())
+	case basetypes.MapValue:
+		return goMap(v.Elements())
+	case basetypes.ObjectValue:
+		return goMap(v.Attributes())
+	}
+
+	return nil, fmt.Errorf("unsupported value type %s", value.Type(nil))
+}
+
+func goNumber(number *big.Float) any {
+	if number.IsInt() {
+		if i, accuracy := number.Int64(); accuracy == big.Exact {
+			return i
+		}
+	}
+
+	f, _ := number.Float64()
+
+	return f
+}
+
+func goList(elements []attr.Value) ([]any, error) {
+	list := make([]any, 0, len(elements))
+	for i, element := range elements {
+		value, err := goValue(element)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		list = append(list, value)
+	}
+
+	return list, nil
+}
+
+func goMap(elements map[string]attr.Value) (map[string]any, error) {
+	m := make(map[string]any, len(elements))
+	for key, element := range elements {
+		value, err := goValue(element)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		m[key] = value
+	}
+
+	return m, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
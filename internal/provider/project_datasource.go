@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &projectDataSource{}
+	_ datasource.DataSourceWithConfigure = &projectDataSource{}
+)
+
+type projectDataSourceModel struct {
+	ProjectID types.String `tfsdk:"project_id"`
+	Name      types.String `tfsdk:"name"`
+	State     types.String `tfsdk:"state"`
+}
+
+type projectDataSource struct {
+	data *providerData
+}
+
+func NewProjectDataSource() datasource.DataSource {
+	return &projectDataSource{}
+}
+
+func (d *projectDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data, diags := providerDataFrom(req.ProviderData, "Data Source")
+	resp.Diagnostics.Append(diags...)
+	if data == nil {
+		return
+	}
+
+	d.data = data
+}
+
+func (d *projectDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+func (d *projectDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a Litmus Chaos project.",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Description: "Project ID. Defaults to the provider `project_id`.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the Project",
+				Computed:    true,
+			},
+			"state": schema.StringAttribute{
+				Description: "State of the Project, e.g. `active`. Empty if the server does not report it.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *projectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state projectDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, diags := d.data.projectID(state.ProjectID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	project, err := d.data.client.GetProject(ctx, projectID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Litmus Chaos Project",
+			"Could not read Litmus Chaos project with ID "+projectID+": "+err.Error(),
+		)
+		return
+	}
+
+	state.ProjectID = types.StringValue(project.ID)
+	state.Name = types.StringValue(project.Name)
+	state.State = types.StringValue("")
+	if project.State != nil {
+		state.State = types.StringValue(*project.State)
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -2,16 +2,19 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	litmus "github.com/williamokano/litmus-chaos-thin-client/pkg/client"
+	"github.com/williamokano/litmus-chaos-thin-client/pkg/entities"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -23,13 +26,14 @@ var (
 
 // projectResource is the resource implementation.
 type projectResource struct {
-	client *litmus.LitmusClient
+	client litmusAPI
 }
 
 type projectResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	LastUpdated types.String `tfsdk:"last_updated"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	LastUpdated types.String   `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewProjectResource() resource.Resource {
@@ -38,22 +42,13 @@ func NewProjectResource() resource.Resource {
 
 // Configure adds the provider configured client to the resource.
 func (r *projectResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	if req.ProviderData == nil {
-		return
-	}
-
-	client, ok := req.ProviderData.(*litmus.LitmusClient)
-
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *litmus.LitmusClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-
+	data, diags := providerDataFrom(req.ProviderData, "Resource")
+	resp.Diagnostics.Append(diags...)
+	if data == nil {
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 // Metadata returns the resource type name.
@@ -62,8 +57,10 @@ func (r *projectResource) Metadata(_ context.Context, req resource.MetadataReque
 }
 
 // Schema defines the schema for the resource.
-func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *projectResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Description: "Manages a Litmus Chaos project. Projects can be imported by ID, or by name with a `name:` prefixed import ID, " +
+			"from `terraform import` or Terraform 1.5+ `import` blocks. Destroying a project only removes it from the Terraform state.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Project ID",
@@ -81,6 +78,14 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Computed:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -94,7 +99,16 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	project, err := r.client.CreateProject(plan.Name.ValueString())
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	project, err := r.client.CreateProject(ctx, plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating project",
@@ -123,7 +137,22 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	project, err := r.client.GetProjectById(state.ID.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	project, err := r.client.GetProject(ctx, state.ID.ValueString())
+	if errors.Is(err, errNotFound) {
+		// Deleted outside of Terraform, so it is planned for creation again
+		tflog.Warn(ctx, "Litmus Chaos project not found, removing it from the state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading Litmus Chaos Project",
@@ -152,7 +181,16 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	_, err := r.client.UpdateProjectName(plan.ID.ValueString(), plan.Name.ValueString())
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	_, err := r.client.UpdateProjectName(ctx, plan.ID.ValueString(), plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating Litmus Chaos Project Name",
@@ -170,20 +208,56 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 }
 
-// Delete deletes the resource and removes the Terraform state on success.
+// Delete only removes the project from the Terraform state, as the provider
+// does not delete projects. The delete timeout is kept so that configurations
+// setting it stay valid, and bounds the removal like any other operation.
 func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state projectResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	tflog.Warn(ctx, "DELETE not implemented as Litmus Chaos doesn't support project deletion")
+	resp.Diagnostics.AddWarning(
+		"Litmus Chaos Project Not Deleted",
+		"Litmus Chaos projects cannot be deleted by the provider. Project "+state.ID.ValueString()+
+			" was only removed from the Terraform state.",
+	)
 }
 
-// ImportState imports the resource to the Terraform state.
+// projectImportNamePrefix marks import IDs holding a project name instead of
+// a project ID.
+const projectImportNamePrefix = "name:"
+
+// ImportState imports the resource to the Terraform state. Projects are
+// imported by ID, or by name with a "name:" prefixed import ID.
 func (r *projectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	var plan projectResourceModel
 
-	project, err := r.client.GetProjectById(req.ID)
+	var (
+		project *entities.Project
+		err     error
+	)
+	if name, ok := strings.CutPrefix(req.ID, projectImportNamePrefix); ok {
+		project, err = findProjectByName(ctx, r.client, name)
+	} else {
+		project, err = r.client.GetProject(ctx, req.ID)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error importing Litmus Chaos Project",
-			"Could not import Litmus Chaos project with ID "+plan.ID.ValueString()+": "+err.Error(),
+			"Could not import Litmus Chaos project "+req.ID+": "+err.Error(),
 		)
 		return
 	}
@@ -191,6 +265,7 @@ func (r *projectResource) ImportState(ctx context.Context, req resource.ImportSt
 	plan.ID = types.StringValue(project.ID)
 	plan.Name = types.StringValue(project.Name)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	plan.Timeouts = nullTimeouts()
 
 	diags := resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -198,3 +273,33 @@ func (r *projectResource) ImportState(ctx context.Context, req resource.ImportSt
 		return
 	}
 }
+
+// findProjectByName returns the only project named name. Project names are
+// not unique, so finding several is an error rather than a guess.
+func findProjectByName(ctx context.Context, api litmusAPI, name string) (*entities.Project, error) {
+	projects, err := api.ListProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []entities.Project
+	for _, project := range projects {
+		if project.Name == name {
+			matches = append(matches, project)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no project is named %q: %w", name, errNotFound)
+	case 1:
+		return &matches[0], nil
+	}
+
+	ids := make([]string, 0, len(matches))
+	for _, project := range matches {
+		ids = append(ids, project.ID)
+	}
+
+	return nil, fmt.Errorf("%d projects are named %q, import one of them by ID instead: %s", len(matches), name, strings.Join(ids, ", "))
+}
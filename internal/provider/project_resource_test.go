@@ -1,12 +1,28 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/williamokano/litmus-chaos-thin-client/pkg/entities"
+	"github.com/williamokano/terraform-provider-litmus-chaos/internal/fakelitmus"
 )
 
-func TestAccOrderResource(t *testing.T) {
+func TestAccProjectResource(t *testing.T) {
+	testAccServer(t)
+
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -14,18 +30,483 @@ func TestAccOrderResource(t *testing.T) {
 			{
 				Config: providerConfig + `
 resource "litmus-chaos_project" "main_project" {
-  name = "Main Project"
+  name = "tf-acc-main-project"
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					// Verify first order item
-					resource.TestCheckResourceAttr("litmus-chaos_project.main_project", "name", "Main Project"),
+					resource.TestCheckResourceAttr("litmus-chaos_project.main_project", "name", "tf-acc-main-project"),
 
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet("litmus-chaos_project.main_project", "id"),
 					resource.TestCheckResourceAttrSet("litmus-chaos_project.main_project", "last_updated"),
 				),
 			},
+			// ImportState testing
+			{
+				ResourceName:            "litmus-chaos_project.main_project",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// ImportState by name testing
+			{
+				ResourceName:            "litmus-chaos_project.main_project",
+				ImportState:             true,
+				ImportStateId:           "name:tf-acc-main-project",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "litmus-chaos_project" "main_project" {
+  name = "tf-acc-renamed-project"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("litmus-chaos_project.main_project", "name", "tf-acc-renamed-project"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestAccProjectResourceFaults(t *testing.T) {
+	server := testAccServer(t)
+	if server == nil {
+		t.Skip("fault injection needs the fake control plane")
+	}
+
+	config := providerConfig + `
+resource "litmus-chaos_project" "main_project" {
+  name = "tf-acc-faulty-project"
+}
+`
+
+	var (
+		projectID string
+		requests  int
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccProjectID("litmus-chaos_project.main_project", &projectID),
+			},
+			// Transient server errors of idempotent requests, such as the
+			// refresh of the project, are retried. Creates are not.
+			{
+				PreConfig: func() {
+					requests = server.Requests("get_project")
+					server.InjectFault("get_project", fakelitmus.Fault{StatusCode: http.StatusServiceUnavailable, Times: 2})
+				},
+				Config: config,
+				Check: func(s *terraform.State) error {
+					if retried := server.Requests("get_project") - requests; retried < 3 {
+						return fmt.Errorf("expected the refresh to be retried, got %d get_project requests", retried)
+					}
+					return resource.TestCheckResourceAttr("litmus-chaos_project.main_project", "id", projectID)(s)
+				},
+			},
+			// Projects deleted out of band are recreated
+			{
+				PreConfig: func() {
+					server.DeleteProject(projectID)
+				},
+				Config: config,
+				Check: func(s *terraform.State) error {
+					var recreatedID string
+					if err := testAccProjectID("litmus-chaos_project.main_project", &recreatedID)(s); err != nil {
+						return err
+					}
+					if recreatedID == projectID {
+						return fmt.Errorf("expected project %s to be recreated", projectID)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// testAccProjectID stores the ID of the project resource name into id.
+func testAccProjectID(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+func TestAccProjectDataSource(t *testing.T) {
+	testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "litmus-chaos_project" "main_project" {
+  name = "tf-acc-data-source-project"
+}
+
+data "litmus-chaos_project" "main_project" {
+  project_id = litmus-chaos_project.main_project.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.litmus-chaos_project.main_project", "project_id", "litmus-chaos_project.main_project", "id"),
+					resource.TestCheckResourceAttr("data.litmus-chaos_project.main_project", "name", "tf-acc-data-source-project"),
+					resource.TestCheckResourceAttr("data.litmus-chaos_project.main_project", "state", "active"),
+				),
+			},
+		},
+	})
+}
+
+// testProjectResourceValue returns a project resource object of s with the
+// given string attributes.
+func testProjectResourceValue(t *testing.T, s rschema.Schema, values map[string]string) tftypes.Value {
+	t.Helper()
+
+	attributes := make(map[string]tftypes.Value, len(values))
+	for name, value := range values {
+		attributes[name] = tftypes.NewValue(tftypes.String, value)
+	}
+
+	return testObject(t, s.Type().TerraformType(context.Background()), attributes)
+}
+
+func testProjectResourceSchema(t *testing.T) rschema.Schema {
+	t.Helper()
+
+	resp := &fwresource.SchemaResponse{}
+	NewProjectResource().Schema(context.Background(), fwresource.SchemaRequest{}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	return resp.Schema
+}
+
+func testProjectGetter(projects ...entities.Project) func(context.Context, string) (*entities.Project, error) {
+	return func(_ context.Context, projectID string) (*entities.Project, error) {
+		for _, project := range projects {
+			if project.ID == projectID {
+				return &project, nil
+			}
+		}
+		return nil, fmt.Errorf("failed to get project by id: %w", errNotFound)
+	}
+}
+
+func testProjectLister(projects ...entities.Project) func(context.Context) ([]entities.Project, error) {
+	return func(context.Context) ([]entities.Project, error) {
+		return projects, nil
+	}
+}
+
+func TestProjectResourceCreate(t *testing.T) {
+	testCases := map[string]struct {
+		api           mockAPI
+		expectedState map[string]string
+		expectedError string
+	}{
+		"created": {
+			api: mockAPI{
+				createProject: func(_ context.Context, projectName string) (*entities.Project, error) {
+					return &entities.Project{ID: "project-id", Name: projectName}, nil
+				},
+			},
+			expectedState: map[string]string{"id": "project-id", "name": "tf-project"},
+		},
+		"api error": {
+			api: mockAPI{
+				createProject: func(context.Context, string) (*entities.Project, error) {
+					return nil, errors.New("name already taken")
+				},
+			},
+			expectedError: "Could not create project, unexpected error: name already taken",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := testProjectResourceSchema(t)
+			r := &projectResource{client: &testCase.api}
+
+			resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}}
+			r.Create(ctx, fwresource.CreateRequest{
+				Plan: tfsdk.Plan{Schema: s, Raw: testProjectResourceValue(t, s, map[string]string{"name": "tf-project"})},
+			}, resp)
+
+			testCheckDiagnostics(t, resp.Diagnostics, testCase.expectedError)
+			if testCase.expectedError != "" {
+				return
+			}
+
+			testCheckState(t, resp.State, testCase.expectedState)
+
+			var lastUpdated types.String
+			resp.State.GetAttribute(ctx, path.Root("last_updated"), &lastUpdated)
+			if lastUpdated.ValueString() == "" {
+				t.Error("expected last_updated to be set")
+			}
+		})
+	}
+}
+
+func TestProjectResourceRead(t *testing.T) {
+	testCases := map[string]struct {
+		api           mockAPI
+		expectedState map[string]string
+		expectedError string
+	}{
+		"refreshed": {
+			api: mockAPI{
+				getProject: testProjectGetter(entities.Project{ID: "project-id", Name: "tf-renamed"}),
+			},
+			expectedState: map[string]string{"id": "project-id", "name": "tf-renamed", "last_updated": "yesterday"},
+		},
+		"deleted out of band": {
+			api: mockAPI{
+				getProject: testProjectGetter(),
+			},
+		},
+		"api error": {
+			api: mockAPI{
+				getProject: func(context.Context, string) (*entities.Project, error) {
+					return nil, errors.New("connection refused")
+				},
+			},
+			expectedError: "Could not read Litmus Chaos Project ID project-id: connection refused",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := testProjectResourceSchema(t)
+			r := &projectResource{client: &testCase.api}
+
+			state := tfsdk.State{Schema: s, Raw: testProjectResourceValue(t, s, map[string]string{
+				"id":           "project-id",
+				"name":         "tf-project",
+				"last_updated": "yesterday",
+			})}
+			resp := &fwresource.ReadResponse{State: state}
+			r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+
+			testCheckDiagnostics(t, resp.Diagnostics, testCase.expectedError)
+			if testCase.expectedError != "" {
+				return
+			}
+
+			testCheckState(t, resp.State, testCase.expectedState)
+		})
+	}
+}
+
+func TestProjectResourceUpdate(t *testing.T) {
+	testCases := map[string]struct {
+		api           mockAPI
+		expectedError string
+	}{
+		"renamed": {
+			api: mockAPI{
+				updateProjectName: func(_ context.Context, projectID string, projectName string) (*entities.Project, error) {
+					return &entities.Project{ID: projectID, Name: projectName}, nil
+				},
+			},
+		},
+		"api error": {
+			api: mockAPI{
+				updateProjectName: func(context.Context, string, string) (*entities.Project, error) {
+					return nil, errors.New("forbidden")
+				},
+			},
+			expectedError: "Could not update Litmus Chaos project name with ID project-id: forbidden",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := testProjectResourceSchema(t)
+			r := &projectResource{client: &testCase.api}
+
+			state := tfsdk.State{Schema: s, Raw: testProjectResourceValue(t, s, map[string]string{
+				"id":           "project-id",
+				"name":         "tf-project",
+				"last_updated": "yesterday",
+			})}
+			resp := &fwresource.UpdateResponse{State: state}
+			r.Update(ctx, fwresource.UpdateRequest{
+				Plan: tfsdk.Plan{Schema: s, Raw: testProjectResourceValue(t, s, map[string]string{
+					"id":           "project-id",
+					"name":         "tf-renamed",
+					"last_updated": "yesterday",
+				})},
+				State: state,
+			}, resp)
+
+			testCheckDiagnostics(t, resp.Diagnostics, testCase.expectedError)
+			if testCase.expectedError != "" {
+				return
+			}
+
+			testCheckState(t, resp.State, map[string]string{"id": "project-id", "name": "tf-renamed"})
+		})
+	}
+}
+
+func TestProjectResourceDelete(t *testing.T) {
+	testCases := map[string]struct {
+		deleteTimeout string
+		expectedError string
+	}{
+		"default timeout": {},
+		"delete timeout": {
+			deleteTimeout: "1m",
+		},
+		"invalid delete timeout": {
+			deleteTimeout: "soon",
+			expectedError: "Timeout Cannot Be Parsed",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := testProjectResourceSchema(t)
+
+			// The mock fails any call, the project must only be removed from state
+			r := &projectResource{client: &mockAPI{}}
+
+			value := testProjectResourceValue(t, s, map[string]string{
+				"id":   "project-id",
+				"name": "tf-project",
+			})
+			if testCase.deleteTimeout != "" {
+				value = testWithTimeouts(t, s, value, map[string]string{"delete": testCase.deleteTimeout})
+			}
+
+			state := tfsdk.State{Schema: s, Raw: value}
+			resp := &fwresource.DeleteResponse{State: state}
+			r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+
+			testCheckDiagnostics(t, resp.Diagnostics, testCase.expectedError)
+			if testCase.expectedError != "" {
+				return
+			}
+
+			warnings := resp.Diagnostics.Warnings()
+			if len(warnings) != 1 || warnings[0].Summary() != "Litmus Chaos Project Not Deleted" ||
+				!strings.Contains(warnings[0].Detail(), "Project project-id was only removed from the Terraform state") {
+				t.Errorf("expected a warning that the project was not deleted, got: %v", warnings)
+			}
+		})
+	}
+}
+
+// testWithTimeouts returns value, an object of s, with its timeouts block set
+// to the given durations.
+func testWithTimeouts(t *testing.T, s rschema.Schema, value tftypes.Value, durations map[string]string) tftypes.Value {
+	t.Helper()
+
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	timeoutsType := s.Type().TerraformType(context.Background()).(tftypes.Object).AttributeTypes["timeouts"] //nolint:forcetypeassert // schemas are objects
+	timeoutsValues := map[string]tftypes.Value{}
+	for name, attributeType := range timeoutsType.(tftypes.Object).AttributeTypes { //nolint:forcetypeassert // the timeouts block is an object
+		timeoutsValues[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, duration := range durations {
+		timeoutsValues[name] = tftypes.NewValue(tftypes.String, duration)
+	}
+	attributes["timeouts"] = tftypes.NewValue(timeoutsType, timeoutsValues)
+
+	return tftypes.NewValue(value.Type(), attributes)
+}
+
+func TestProjectResourceImportState(t *testing.T) {
+	testCases := map[string]struct {
+		importID      string
+		api           mockAPI
+		expectedState map[string]string
+		expectedError string
+	}{
+		"imported": {
+			api: mockAPI{
+				getProject: testProjectGetter(entities.Project{ID: "project-id", Name: "tf-project"}),
+			},
+			expectedState: map[string]string{"id": "project-id", "name": "tf-project"},
+		},
+		"not found": {
+			api: mockAPI{
+				getProject: testProjectGetter(),
+			},
+			expectedError: "Could not import Litmus Chaos project project-id: failed to get project by id: not found",
+		},
+		"by name": {
+			importID: "name:Main Project",
+			api: mockAPI{
+				listProjects: testProjectLister(
+					entities.Project{ID: "other-id", Name: "Other Project"},
+					entities.Project{ID: "project-id", Name: "Main Project"},
+				),
+			},
+			expectedState: map[string]string{"id": "project-id", "name": "Main Project"},
+		},
+		"by missing name": {
+			importID: "name:Main Project",
+			api: mockAPI{
+				listProjects: testProjectLister(entities.Project{ID: "other-id", Name: "Other Project"}),
+			},
+			expectedError: `Could not import Litmus Chaos project name:Main Project: no project is named "Main Project"`,
+		},
+		"by ambiguous name": {
+			importID: "name:Main Project",
+			api: mockAPI{
+				listProjects: testProjectLister(
+					entities.Project{ID: "project-id", Name: "Main Project"},
+					entities.Project{ID: "other-id", Name: "Main Project"},
+				),
+			},
+			expectedError: `2 projects are named "Main Project", import one of them by ID instead: project-id, other-id`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := testProjectResourceSchema(t)
+			r := &projectResource{client: &testCase.api}
+
+			importID := testCase.importID
+			if importID == "" {
+				importID = "project-id"
+			}
+
+			resp := &fwresource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}}
+			r.ImportState(ctx, fwresource.ImportStateRequest{ID: importID}, resp)
+
+			testCheckDiagnostics(t, resp.Diagnostics, testCase.expectedError)
+			if testCase.expectedError != "" {
+				return
+			}
+
+			testCheckState(t, resp.State, testCase.expectedState)
+		})
+	}
+}
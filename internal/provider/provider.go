@@ -2,21 +2,30 @@ package provider
 
 import (
 	"context"
+	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/williamokano/litmus-chaos-thin-client/pkg/client"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &litmusChaosProvider{}
+	_ provider.Provider                       = &litmusChaosProvider{}
+	_ provider.ProviderWithFunctions          = &litmusChaosProvider{}
+	_ provider.ProviderWithEphemeralResources = &litmusChaosProvider{}
 )
 
 // litmusChaosProviderModel maps provider schema data to a Go type.
@@ -25,6 +34,44 @@ type litmusChaosProviderModel struct {
 	Token    types.String `tfsdk:"token"`
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
+
+	TokenFile    types.String       `tfsdk:"token_file"`
+	PasswordFile types.String       `tfsdk:"password_file"`
+	Exec         *providerExecModel `tfsdk:"exec"`
+
+	AuthEndpoint types.String `tfsdk:"auth_endpoint"`
+	APIEndpoint  types.String `tfsdk:"api_endpoint"`
+
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCertPEM      types.String `tfsdk:"client_cert_pem"`
+	ClientKeyPEM       types.String `tfsdk:"client_key_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+
+	ProxyURL types.String `tfsdk:"proxy_url"`
+	NoProxy  types.String `tfsdk:"no_proxy"`
+	Headers  types.Map    `tfsdk:"headers"`
+
+	SensitiveHeaders types.List `tfsdk:"sensitive_headers"`
+
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.String `tfsdk:"retry_wait_max"`
+
+	RequestTimeout types.String `tfsdk:"request_timeout"`
+
+	ProjectID types.String `tfsdk:"project_id"`
+
+	ValidateOnConfigure types.Bool `tfsdk:"validate_on_configure"`
+}
+
+// providerExecModel maps the exec block, configuring a credential helper.
+type providerExecModel struct {
+	Command types.String `tfsdk:"command"`
+	Args    types.List   `tfsdk:"args"`
+	Env     types.Map    `tfsdk:"env"`
+
+	SensitiveEnv types.List `tfsdk:"sensitive_env"`
 }
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -59,20 +106,129 @@ func (p *litmusChaosProvider) Schema(_ context.Context, _ provider.SchemaRequest
 				Description: "URI for Litmus Chaos Control Plane. May also be provided via `LITMUS_CHAOS_HOST` environment variable.",
 				Optional:    true,
 			},
+			"auth_endpoint": schema.StringAttribute{
+				Description: "URL of the Litmus Chaos authentication server, for setups exposing it separately. Defaults to `<host>/auth`. May also be provided via `LITMUS_CHAOS_AUTH_ENDPOINT` environment variable.",
+				Optional:    true,
+			},
+			"api_endpoint": schema.StringAttribute{
+				Description: "URL of the Litmus Chaos GraphQL server query endpoint, for setups exposing it separately. Defaults to `<host>/api/query`. May also be provided via `LITMUS_CHAOS_API_ENDPOINT` environment variable.",
+				Optional:    true,
+			},
 			"username": schema.StringAttribute{
 				Description: "Username for Litmus Chaos server. May also be provided via `LITMUS_CHAOS_USERNAME` environment variable.",
 				Optional:    true,
 			},
 			"password": schema.StringAttribute{
-				Description: "Password for Litmus Chaos server. May also be provided via `LITMUS_CHAOS_PASSWORD` environment variable.",
+				Description: "Password for Litmus Chaos server. The token obtained with it is renewed automatically when it expires. May also be provided via `LITMUS_CHAOS_PASSWORD` environment variable.",
 				Optional:    true,
 				Sensitive:   true,
 			},
 			"token": schema.StringAttribute{
-				Description: "API Token for Litmus Chaos Control Plane API. May also be provided via `LITMUS_CHAOS_TOKEN` environment variable.",
+				Description: "API Token for Litmus Chaos Control Plane API. May also be provided via `LITMUS_CHAOS_TOKEN` environment variable. Credentials set in the configuration, including `token_file`, `password_file` and `exec`, take precedence over any set in the environment, and a token over a password.",
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"token_file": schema.StringAttribute{
+				Description: "Path to a file containing the API token. Conflicts with `token` and `exec`. May also be provided via `LITMUS_CHAOS_TOKEN_FILE` environment variable.",
+				Optional:    true,
+			},
+			"password_file": schema.StringAttribute{
+				Description: "Path to a file containing the password. Conflicts with `password`. May also be provided via `LITMUS_CHAOS_PASSWORD_FILE` environment variable.",
+				Optional:    true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded CA certificate(s) trusted in addition to the system ones. May also be provided via `LITMUS_CHAOS_CA_CERT_PEM` environment variable.",
+				Optional:    true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a file with PEM encoded CA certificate(s) trusted in addition to the system ones. May also be provided via `LITMUS_CHAOS_CA_CERT_FILE` environment variable.",
+				Optional:    true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded client certificate for mutual TLS. Requires `client_key_pem`. May also be provided via `LITMUS_CHAOS_CLIENT_CERT_PEM` environment variable.",
+				Optional:    true,
+			},
+			"client_key_pem": schema.StringAttribute{
+				Description: "PEM encoded private key of the client certificate for mutual TLS. May also be provided via `LITMUS_CHAOS_CLIENT_KEY_PEM` environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Skip verification of the control plane TLS certificate. Only meant for testing. May also be provided via `LITMUS_CHAOS_INSECURE_SKIP_VERIFY` environment variable.",
+				Optional:    true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of the proxy used to reach the control plane, credentials for an authenticating proxy may be part of the URL. " +
+					"Defaults to the standard `HTTP_PROXY`/`HTTPS_PROXY` environment variables. May also be provided via `LITMUS_CHAOS_PROXY_URL` environment variable.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"no_proxy": schema.StringAttribute{
+				Description: "Comma-separated list of hosts that are reached without the proxy. " +
+					"Defaults to the standard `NO_PROXY` environment variable. May also be provided via `LITMUS_CHAOS_NO_PROXY` environment variable.",
+				Optional: true,
+			},
+			"headers": schema.MapAttribute{
+				Description: "Additional headers sent with every request to the control plane, for instance for ingress routing. `Authorization` and `Content-Type` are set by the provider and cannot be configured.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"sensitive_headers": schema.ListAttribute{
+				Description: "Names of the `headers` carrying secrets, such as API keys of a gateway. Their values are masked in logs and errors.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Maximum number of retries of requests failing with transient errors, such as 502 or 503 responses during upgrades. Set to 0 to disable retries. Defaults to 3. May also be provided via `LITMUS_CHAOS_MAX_RETRIES` environment variable.",
+				Optional:    true,
+			},
+			"retry_wait_min": schema.StringAttribute{
+				Description: "Minimum time to wait before retrying, doubled on every attempt, e.g. `500ms`. Defaults to `1s`. May also be provided via `LITMUS_CHAOS_RETRY_WAIT_MIN` environment variable.",
+				Optional:    true,
+			},
+			"retry_wait_max": schema.StringAttribute{
+				Description: "Maximum time to wait before retrying, including when the server asks for more with a `Retry-After` header. Defaults to `30s`. May also be provided via `LITMUS_CHAOS_RETRY_WAIT_MAX` environment variable.",
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Maximum time a single request to the control plane may take, e.g. `30s`. Every retry gets its own budget. Defaults to `1m`. May also be provided via `LITMUS_CHAOS_REQUEST_TIMEOUT` environment variable.",
+				Optional:    true,
+			},
+			"project_id": schema.StringAttribute{
+				Description: "Default project of the data sources and resources that do not set their own `project_id`, such as `litmus-chaos_project` and `litmus-chaos_environment`. Resources record the project in state, so changing the default replaces the resources inheriting it. May also be provided via `LITMUS_CHAOS_PROJECT_ID` environment variable.",
+				Optional:    true,
+			},
+			"validate_on_configure": schema.BoolAttribute{
+				Description: "Check that the control plane can be reached and accepts the credentials when configuring the provider, reporting problems against the offending attribute. Defaults to `true`. May also be provided via `LITMUS_CHAOS_VALIDATE_ON_CONFIGURE` environment variable.",
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"exec": schema.SingleNestedBlock{
+				Description: "Credential helper the API token is obtained from. Conflicts with `token` and `token_file`. " +
+					"The command must print a JSON object with a `token` and optionally an RFC 3339 `expires_at`, and is run again once the token expires.",
+				Attributes: map[string]schema.Attribute{
+					"command": schema.StringAttribute{
+						Description: "Command to run.",
+						Optional:    true,
+					},
+					"args": schema.ListAttribute{
+						Description: "Arguments passed to the command.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"env": schema.MapAttribute{
+						Description: "Environment variables set for the command in addition to the ones of the provider.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"sensitive_env": schema.ListAttribute{
+						Description: "Names of the `env` variables carrying secrets. Their values are masked in logs and errors.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -128,6 +284,57 @@ func (p *litmusChaosProvider) Configure(ctx context.Context, req provider.Config
 		)
 	}
 
+	for _, attribute := range []struct {
+		name    string
+		value   attr.Value
+		envName string
+	}{
+		{"token_file", config.TokenFile, "LITMUS_CHAOS_TOKEN_FILE"},
+		{"password_file", config.PasswordFile, "LITMUS_CHAOS_PASSWORD_FILE"},
+		{"auth_endpoint", config.AuthEndpoint, "LITMUS_CHAOS_AUTH_ENDPOINT"},
+		{"api_endpoint", config.APIEndpoint, "LITMUS_CHAOS_API_ENDPOINT"},
+		{"ca_cert_pem", config.CACertPEM, "LITMUS_CHAOS_CA_CERT_PEM"},
+		{"ca_cert_file", config.CACertFile, "LITMUS_CHAOS_CA_CERT_FILE"},
+		{"client_cert_pem", config.ClientCertPEM, "LITMUS_CHAOS_CLIENT_CERT_PEM"},
+		{"client_key_pem", config.ClientKeyPEM, "LITMUS_CHAOS_CLIENT_KEY_PEM"},
+		{"insecure_skip_verify", config.InsecureSkipVerify, "LITMUS_CHAOS_INSECURE_SKIP_VERIFY"},
+		{"proxy_url", config.ProxyURL, "LITMUS_CHAOS_PROXY_URL"},
+		{"no_proxy", config.NoProxy, "LITMUS_CHAOS_NO_PROXY"},
+		{"max_retries", config.MaxRetries, "LITMUS_CHAOS_MAX_RETRIES"},
+		{"retry_wait_min", config.RetryWaitMin, "LITMUS_CHAOS_RETRY_WAIT_MIN"},
+		{"retry_wait_max", config.RetryWaitMax, "LITMUS_CHAOS_RETRY_WAIT_MAX"},
+		{"request_timeout", config.RequestTimeout, "LITMUS_CHAOS_REQUEST_TIMEOUT"},
+		{"project_id", config.ProjectID, "LITMUS_CHAOS_PROJECT_ID"},
+		{"validate_on_configure", config.ValidateOnConfigure, "LITMUS_CHAOS_VALIDATE_ON_CONFIGURE"},
+	} {
+		if attribute.value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute.name),
+				"Unknown Litmus Chaos Client Configuration",
+				"The provider cannot create the Litmus Chaos API client as there is an unknown configuration value for "+attribute.name+". "+
+					"Either target apply the source of the value first, set the value statically in the configuration, or use the "+attribute.envName+" environment variable.",
+			)
+		}
+	}
+
+	if config.Exec != nil && (config.Exec.Command.IsUnknown() || config.Exec.Args.IsUnknown() || config.Exec.Env.IsUnknown() || config.Exec.SensitiveEnv.IsUnknown()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("exec"),
+			"Unknown Litmus Chaos Client Configuration",
+			"The provider cannot create the Litmus Chaos API client as there is an unknown configuration value in the exec block. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.Headers.IsUnknown() || config.SensitiveHeaders.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("headers"),
+			"Unknown Litmus Chaos Client Configuration",
+			"The provider cannot create the Litmus Chaos API client as there is an unknown configuration value for headers. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -135,45 +342,279 @@ func (p *litmusChaosProvider) Configure(ctx context.Context, req provider.Config
 	// Default values to environment variables, but override
 	// with Terraform configuration value if set.
 
-	var (
-		host     = os.Getenv("LITMUS_CHAOS_HOST")
-		token    = os.Getenv("LITMUS_CHAOS_TOKEN")
-		username = os.Getenv("LITMUS_CHAOS_USERNAME")
-		password = os.Getenv("LITMUS_CHAOS_PASSWORD")
-	)
+	host := os.Getenv("LITMUS_CHAOS_HOST")
 
 	if !config.Host.IsNull() {
 		host = config.Host.ValueString()
 	}
 
-	if !config.Token.IsNull() {
-		token = config.Token.ValueString()
+	var (
+		authEndpoint = os.Getenv("LITMUS_CHAOS_AUTH_ENDPOINT")
+		apiEndpoint  = os.Getenv("LITMUS_CHAOS_API_ENDPOINT")
+	)
+
+	if !config.AuthEndpoint.IsNull() {
+		authEndpoint = config.AuthEndpoint.ValueString()
+	}
+
+	if !config.APIEndpoint.IsNull() {
+		apiEndpoint = config.APIEndpoint.ValueString()
+	}
+
+	// Credentials set in the configuration, including from files or a
+	// credential helper, win over any set in the environment
+	creds, diags := resolveCredentials(ctx, config)
+	resp.Diagnostics.Append(diags...)
+
+	var (
+		token            = creds.Token
+		username         = creds.Username
+		password         = creds.Password
+		credentialHelper = creds.Exec
+	)
+
+	tlsSettings := tlsSettings{
+		CACertPEM:     os.Getenv("LITMUS_CHAOS_CA_CERT_PEM"),
+		CACertFile:    os.Getenv("LITMUS_CHAOS_CA_CERT_FILE"),
+		ClientCertPEM: os.Getenv("LITMUS_CHAOS_CLIENT_CERT_PEM"),
+		ClientKeyPEM:  os.Getenv("LITMUS_CHAOS_CLIENT_KEY_PEM"),
+	}
+
+	if v := os.Getenv("LITMUS_CHAOS_INSECURE_SKIP_VERIFY"); v != "" {
+		insecureSkipVerify, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("insecure_skip_verify"),
+				"Invalid Litmus Chaos TLS Configuration",
+				"The LITMUS_CHAOS_INSECURE_SKIP_VERIFY environment variable must be a boolean, got: "+v,
+			)
+		}
+		tlsSettings.InsecureSkipVerify = insecureSkipVerify
+	}
+
+	if !config.CACertPEM.IsNull() {
+		tlsSettings.CACertPEM = config.CACertPEM.ValueString()
+	}
+
+	if !config.CACertFile.IsNull() {
+		tlsSettings.CACertFile = config.CACertFile.ValueString()
+	}
+
+	if !config.ClientCertPEM.IsNull() {
+		tlsSettings.ClientCertPEM = config.ClientCertPEM.ValueString()
+	}
+
+	if !config.ClientKeyPEM.IsNull() {
+		tlsSettings.ClientKeyPEM = config.ClientKeyPEM.ValueString()
+	}
+
+	if !config.InsecureSkipVerify.IsNull() {
+		tlsSettings.InsecureSkipVerify = config.InsecureSkipVerify.ValueBool()
+	}
+
+	proxySettings := proxySettings{
+		ProxyURL: os.Getenv("LITMUS_CHAOS_PROXY_URL"),
+		NoProxy:  os.Getenv("LITMUS_CHAOS_NO_PROXY"),
 	}
 
-	if !config.Username.IsNull() {
-		username = config.Username.ValueString()
+	if !config.ProxyURL.IsNull() {
+		proxySettings.ProxyURL = config.ProxyURL.ValueString()
 	}
 
-	if !config.Password.IsNull() {
-		password = config.Password.ValueString()
+	if !config.NoProxy.IsNull() {
+		proxySettings.NoProxy = config.NoProxy.ValueString()
+	}
+
+	retrySettings := retrySettings{
+		MaxRetries: 3,
+		WaitMin:    time.Second,
+		WaitMax:    30 * time.Second,
+	}
+
+	if v := os.Getenv("LITMUS_CHAOS_MAX_RETRIES"); v != "" {
+		maxRetries, err := strconv.Atoi(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Litmus Chaos Retry Configuration",
+				"The LITMUS_CHAOS_MAX_RETRIES environment variable must be an integer, got: "+v,
+			)
+		}
+		retrySettings.MaxRetries = maxRetries
+	}
+
+	if !config.MaxRetries.IsNull() {
+		retrySettings.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	if retrySettings.MaxRetries < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid Litmus Chaos Retry Configuration",
+			"max_retries cannot be negative.",
+		)
+	}
+
+	for _, wait := range []struct {
+		name    string
+		value   types.String
+		envName string
+		target  *time.Duration
+	}{
+		{"retry_wait_min", config.RetryWaitMin, "LITMUS_CHAOS_RETRY_WAIT_MIN", &retrySettings.WaitMin},
+		{"retry_wait_max", config.RetryWaitMax, "LITMUS_CHAOS_RETRY_WAIT_MAX", &retrySettings.WaitMax},
+	} {
+		v := os.Getenv(wait.envName)
+		if !wait.value.IsNull() {
+			v = wait.value.ValueString()
+		}
+
+		if v == "" {
+			continue
+		}
+
+		// A zero wait would make the exponential backoff wait for
+		// retry_wait_max on every attempt
+		duration, err := time.ParseDuration(v)
+		if err != nil || duration <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(wait.name),
+				"Invalid Litmus Chaos Retry Configuration",
+				wait.name+" must be a positive duration such as 500ms or 10s, got: "+v,
+			)
+			continue
+		}
+		*wait.target = duration
+	}
+
+	if retrySettings.WaitMin > retrySettings.WaitMax {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid Litmus Chaos Retry Configuration",
+			"retry_wait_min cannot be greater than retry_wait_max.",
+		)
+	}
+
+	validateOnConfigure := true
+	if v := os.Getenv("LITMUS_CHAOS_VALIDATE_ON_CONFIGURE"); v != "" {
+		var err error
+		validateOnConfigure, err = strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("validate_on_configure"),
+				"Invalid Litmus Chaos Client Configuration",
+				"The LITMUS_CHAOS_VALIDATE_ON_CONFIGURE environment variable must be a boolean, got: "+v,
+			)
+		}
+	}
+
+	if !config.ValidateOnConfigure.IsNull() {
+		validateOnConfigure = config.ValidateOnConfigure.ValueBool()
+	}
+
+	projectID := os.Getenv("LITMUS_CHAOS_PROJECT_ID")
+	if !config.ProjectID.IsNull() {
+		projectID = config.ProjectID.ValueString()
+	}
+
+	requestTimeout := time.Minute
+	if v := os.Getenv("LITMUS_CHAOS_REQUEST_TIMEOUT"); v != "" || !config.RequestTimeout.IsNull() {
+		if !config.RequestTimeout.IsNull() {
+			v = config.RequestTimeout.ValueString()
+		}
+
+		duration, err := time.ParseDuration(v)
+		if err != nil || duration <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid Litmus Chaos Request Timeout",
+				"request_timeout must be a positive duration such as 30s or 2m, got: "+v,
+			)
+		}
+		requestTimeout = duration
+	}
+
+	headers := map[string]string{}
+	if !config.Headers.IsNull() {
+		diags = config.Headers.ElementsAs(ctx, &headers, false)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	var sensitiveHeaders []string
+	if !config.SensitiveHeaders.IsNull() {
+		diags = config.SensitiveHeaders.ElementsAs(ctx, &sensitiveHeaders, false)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	for _, name := range sensitiveHeaders {
+		if _, ok := headerValue(headers, name); !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("sensitive_headers"),
+				"Invalid Litmus Chaos Headers",
+				"The "+name+" header is listed in sensitive_headers but not configured in headers.",
+			)
+		}
+	}
+
+	for name := range headers {
+		for _, reserved := range reservedHeaders {
+			if http.CanonicalHeaderKey(name) == reserved {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("headers"),
+					"Invalid Litmus Chaos Headers",
+					"The "+name+" header is set by the provider and cannot be configured in headers.",
+				)
+			}
+		}
 	}
 
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
-	if host == "" {
+	// The host can be omitted when both endpoints are set explicitly
+	if host == "" && (authEndpoint == "" || apiEndpoint == "") {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("host"),
 			"Missing Litmus Chaos Control Plane Host",
 			"The provider cannot create the Litmus Chaos API client as there is a missing or empty value for the Litmus Chaos API host. "+
 				"Set the host value in the configuration or use the LITMUS_CHAOS_HOST environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"If either is already set, ensure the value is not empty. "+
+				"This can be empty if auth_endpoint and api_endpoint are BOTH provided.",
 		)
 	}
 
+	// Connection problems are reported against the attributes the practitioner
+	// actually configured
+	attributes := connectionAttributes{
+		AuthEndpoint: path.Root("host"),
+		APIEndpoint:  path.Root("host"),
+		Credentials:  creds.Attribute,
+		CACert:       path.Root("ca_cert_pem"),
+	}
+
+	if authEndpoint != "" {
+		attributes.AuthEndpoint = path.Root("auth_endpoint")
+	}
+
+	if apiEndpoint != "" {
+		attributes.APIEndpoint = path.Root("api_endpoint")
+	}
+
+	if tlsSettings.CACertFile != "" {
+		attributes.CACert = path.Root("ca_cert_file")
+	}
+
+	if authEndpoint == "" {
+		authEndpoint = defaultAuthEndpoint(host)
+	}
+
+	if apiEndpoint == "" {
+		apiEndpoint = defaultAPIEndpoint(host)
+	}
+
 	// If token is not provided, fallback to username and pass. Messages related to token
 	// must be preferential as username/password is not secure
-	if token == "" {
+	if token == "" && credentialHelper == nil {
 		// No fallback
 		if username == "" && password == "" {
 			resp.Diagnostics.AddAttributeError(
@@ -182,7 +623,7 @@ func (p *litmusChaosProvider) Configure(ctx context.Context, req provider.Config
 				"The provider cannot create the Litmus Chaos client as there is a missing or empty value for the Litmus Chaos API token. "+
 					"Set the token value in the configuration or use the LITMUS_CHAOS_TOKEN environment variable. "+
 					"If either is already set, ensure the value is not empty. "+
-					"This can be empty if username and password are BOTH provided. ",
+					"This can be empty if token_file, an exec block, or username and password are provided. ",
 			)
 		} else {
 
@@ -215,34 +656,137 @@ func (p *litmusChaosProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
+	// Every secret the provider knows about is masked by value from here on,
+	// in logs as well as in errors returned by the server. Other header and
+	// environment values are left alone: masking routing values such as "1"
+	// would mangle every message containing them.
+	redactor := &redactor{}
+	redactor.Add(token, password, tlsSettings.ClientKeyPEM)
+
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+
+	for _, name := range sensitiveHeaders {
+		value, _ := headerValue(headers, name)
+		redactor.Add(value)
+	}
+
+	if proxyURL, err := url.Parse(proxySettings.ProxyURL); err == nil && proxyURL.User != nil {
+		if proxyPassword, ok := proxyURL.User.Password(); ok {
+			redactor.Add(proxyPassword)
+		}
+	}
+
+	if credentialHelper != nil {
+		for _, name := range credentialHelper.SensitiveEnv {
+			redactor.Add(credentialHelper.Env[name])
+		}
+	}
+
+	ctx = redactor.Context(ctx)
 	ctx = tflog.SetField(ctx, "litmus_chaos_host", host)
-	ctx = tflog.SetField(ctx, "litmus_chaos_token", token)
+	ctx = tflog.SetField(ctx, "litmus_chaos_auth_endpoint", authEndpoint)
+	ctx = tflog.SetField(ctx, "litmus_chaos_api_endpoint", apiEndpoint)
+	ctx = tflog.SetField(ctx, "litmus_chaos_project_id", projectID)
 	ctx = tflog.SetField(ctx, "litmus_chaos_username", username)
-	ctx = tflog.SetField(ctx, "litmus_chaos_password", password)
-	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "litmus_chaos_token", "litmus_chaos_password")
+	ctx = tflog.SetField(ctx, "litmus_chaos_credentials", attributes.Credentials.String())
+	ctx = tflog.SetField(ctx, "litmus_chaos_proxy_url", redactor.Redact(proxySettings.ProxyURL))
+	ctx = tflog.SetField(ctx, "litmus_chaos_headers", headerNames)
 
 	tflog.Debug(ctx, "Creating Litmus Chaos client")
 
-	// Create a new Litmus Chaos client using the configuration values
-	litmusClient, err := client.NewClientFromCredentials(host, client.LitmusCredentials{
-		Username: username,
-		Password: password,
-		Token:    token,
-	})
+	tlsConfig, err := tlsSettings.tlsConfig()
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Litmus Chaos Client",
-			"An unexpected error occurred when creating the Litmus Chaos client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
-				"Litmus Chaos Client Error: "+err.Error(),
+			"Invalid Litmus Chaos TLS Configuration",
+			"The provider cannot create the Litmus Chaos API client as the TLS configuration is invalid: "+err.Error(),
 		)
 		return
 	}
 
+	proxy, err := proxySettings.proxyFunc()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_url"),
+			"Invalid Litmus Chaos Proxy Configuration",
+			"The provider cannot create the Litmus Chaos API client as the proxy configuration is invalid: "+err.Error(),
+		)
+		return
+	}
+
+	transport := newTimeoutTransport(newLoggingTransport(newTransport(tlsConfig, proxy, headers), redactor), requestTimeout)
+	if retrySettings.MaxRetries > 0 {
+		transport, err = newRetryTransport(transport, retrySettings, apiEndpoint)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_endpoint"),
+				"Invalid Litmus Chaos API Endpoint",
+				"The provider cannot create the Litmus Chaos API client as the API endpoint is invalid: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// The thin client cannot be given a transport, so every request is made
+	// by the API implementations through this HTTP client.
+	httpClient := &http.Client{Transport: transport}
+
+	authEndpoint = strings.TrimSuffix(authEndpoint, "/")
+
+	// Tokens obtained from a credential helper or from username and password
+	// are refreshed when they expire, a configured token is used as is
+	tokens := staticTokenSource(token)
+	if token == "" {
+		if credentialHelper != nil {
+			tokens = execTokenSource(*credentialHelper)
+		} else {
+			tokens = loginTokenSource(httpClient, authEndpoint, username, password)
+		}
+		tokens.redactor = redactor
+	}
+
+	client := &apiClient{
+		authEndpoint: authEndpoint,
+		apiEndpoint:  apiEndpoint,
+		tokens:       tokens,
+		httpClient:   httpClient,
+		username:     username,
+		password:     password,
+		redactor:     redactor,
+	}
+
+	if validateOnConfigure {
+		tflog.Debug(ctx, "Validating Litmus Chaos connection")
+
+		resp.Diagnostics.Append(validateConnection(ctx, client, attributes)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if token == "" {
+		// Obtain the first token up front
+		if _, err := tokens.Token(ctx); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Create Litmus Chaos Client",
+				"An unexpected error occurred when creating the Litmus Chaos client. "+
+					"If the error is not clear, please contact the provider developers.\n\n"+
+					"Litmus Chaos Client Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// The API implementation matching the server version is picked on first
+	// use, so that a provider that is not used does not contact the server
+	api := newLitmusAPI(client)
+
 	// Make the Litmus Chaos client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = litmusClient
-	resp.ResourceData = litmusClient
+	data := newProviderData(api, projectID)
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	resp.EphemeralResourceData = data
 
 	tflog.Info(ctx, "Configured Litmus Chaos client", map[string]any{"success": true})
 }
@@ -251,6 +795,26 @@ func (p *litmusChaosProvider) Configure(ctx context.Context, req provider.Config
 func (p *litmusChaosProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewUserDataSource,
+		NewProjectDataSource,
+		NewCurrentUserDataSource,
+		NewServerInfoDataSource,
+	}
+}
+
+// EphemeralResources defines the ephemeral resources implemented in the
+// provider.
+func (p *litmusChaosProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewTokenEphemeralResource,
+	}
+}
+
+// Functions defines the functions implemented in the provider.
+func (p *litmusChaosProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewChaosEngineFunction,
+		NewParseExperimentFunction,
+		NewValidateExperimentFunction,
 	}
 }
 
@@ -258,5 +822,7 @@ func (p *litmusChaosProvider) DataSources(_ context.Context) []func() datasource
 func (p *litmusChaosProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewProjectResource,
+		NewEnvironmentResource,
+		NewUserPasswordResource,
 	}
 }
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// providerData is what Configure hands to data sources and resources.
+type providerData struct {
+	// client talks to the control plane, caching lookups for the duration of
+	// the Terraform command.
+	client litmusAPI
+
+	// defaultProjectID is the provider project_id, inherited by project
+	// scoped data sources and resources that do not set their own.
+	defaultProjectID string
+}
+
+func newProviderData(api litmusAPI, defaultProjectID string) *providerData {
+	return &providerData{
+		client:           newCachingAPI(api),
+		defaultProjectID: defaultProjectID,
+	}
+}
+
+// providerDataFrom returns the providerData handed to the Configure method of
+// a data source or resource, kind being "Data Source" or "Resource". It is nil
+// when the provider is not configured yet, as happens during validation.
+func providerDataFrom(data any, kind string) (*providerData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if data == nil {
+		return nil, diags
+	}
+
+	configured, ok := data.(*providerData)
+	if !ok {
+		diags.AddError(
+			"Unexpected "+kind+" Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", data),
+		)
+	}
+
+	return configured, diags
+}
+
+// projectID returns the effective project of a project scoped object: its own
+// project_id when set, the provider default otherwise.
+func (d *providerData) projectID(configured types.String) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !configured.IsNull() && !configured.IsUnknown() && configured.ValueString() != "" {
+		return configured.ValueString(), diags
+	}
+
+	if d.defaultProjectID == "" {
+		diags.AddAttributeError(
+			path.Root("project_id"),
+			"Missing Litmus Chaos Project ID",
+			"The project_id attribute must be set as the provider does not configure a default project_id. "+
+				"Either set project_id here, set project_id in the provider configuration, or use the LITMUS_CHAOS_PROJECT_ID environment variable.",
+		)
+	}
+
+	return d.defaultProjectID, diags
+}
+
+// planProjectID plans the effective project of a project scoped resource,
+// whose project_id attribute is optional and computed. It is recorded in
+// state, so that changing the provider default replaces the resources
+// inheriting it rather than moving them silently.
+func (d *providerData) planProjectID(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var configured types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("project_id"), &configured)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || configured.IsUnknown() {
+		return
+	}
+
+	projectID, diags := d.projectID(configured)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.Plan.SetAttribute(ctx, path.Root("project_id"), projectID)
+	resp.Diagnostics.Append(diags...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var prior types.String
+	diags = req.State.GetAttribute(ctx, path.Root("project_id"), &prior)
+	resp.Diagnostics.Append(diags...)
+	if prior.ValueString() != projectID {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("project_id"))
+	}
+}
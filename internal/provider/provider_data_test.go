@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProviderDataFrom(t *testing.T) {
+	if data, diags := providerDataFrom(nil, "Resource"); data != nil || diags.HasError() {
+		t.Errorf("expected nothing before the provider is configured, got %+v (%v)", data, diags)
+	}
+
+	expected := newProviderData(&mockAPI{}, "")
+	if data, diags := providerDataFrom(expected, "Resource"); data != expected || diags.HasError() {
+		t.Errorf("expected the provider data, got %+v (%v)", data, diags)
+	}
+
+	data, diags := providerDataFrom("client", "Data Source")
+	if data != nil || !diags.HasError() || diags[0].Summary() != "Unexpected Data Source Configure Type" {
+		t.Errorf("expected an error, got %+v (%v)", data, diags)
+	}
+}
+
+func TestProviderDataProjectID(t *testing.T) {
+	testCases := map[string]struct {
+		defaultProjectID string
+		configured       types.String
+		expected         string
+		expectedError    bool
+	}{
+		"configured": {
+			defaultProjectID: "default-id",
+			configured:       types.StringValue("project-id"),
+			expected:         "project-id",
+		},
+		"inherited": {
+			defaultProjectID: "default-id",
+			configured:       types.StringNull(),
+			expected:         "default-id",
+		},
+		"missing": {
+			configured:    types.StringNull(),
+			expectedError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual, diags := newProviderData(&mockAPI{}, testCase.defaultProjectID).projectID(testCase.configured)
+			if diags.HasError() != testCase.expectedError {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if actual != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, actual)
+			}
+		})
+	}
+}
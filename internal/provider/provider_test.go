@@ -1,16 +1,19 @@
 package provider
 
 import (
+	"os"
+	"testing"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/williamokano/terraform-provider-litmus-chaos/internal/fakelitmus"
 )
 
 const (
+	// providerConfig leaves the connection to the environment, set up by
+	// testAccServer.
 	providerConfig = `
-provider "litmus-chaos" {
-  host     = "http://localhost:1337"
-  token    = "veryfaketoken"
-}
+provider "litmus-chaos" {}
 `
 )
 
@@ -23,3 +26,25 @@ var (
 		"litmus-chaos": providerserver.NewProtocol6WithError(New("test")()),
 	}
 )
+
+// testAccServer points the provider at a fresh in-process fake control plane,
+// customized by options, for the duration of the test. When LITMUS_CHAOS_HOST
+// is set, acceptance tests run against that control plane instead and nil is
+// returned, so tests relying on the fake must skip.
+func testAccServer(t *testing.T, options ...fakelitmus.Option) *fakelitmus.Server {
+	t.Helper()
+
+	if os.Getenv("LITMUS_CHAOS_HOST") != "" {
+		return nil
+	}
+
+	server := fakelitmus.NewServer(options...)
+	t.Cleanup(server.Close)
+
+	t.Setenv("LITMUS_CHAOS_HOST", server.URL)
+	t.Setenv("LITMUS_CHAOS_TOKEN", "")
+	t.Setenv("LITMUS_CHAOS_USERNAME", fakelitmus.AdminUsername)
+	t.Setenv("LITMUS_CHAOS_PASSWORD", fakelitmus.AdminPassword)
+
+	return server
+}
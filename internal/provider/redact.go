@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redactedPlaceholder replaces secrets in logs and errors.
+const redactedPlaceholder = "***"
+
+// minSecretLength is the length below which values are not treated as
+// secrets. Masking short values would mangle unrelated text, such as status
+// codes, in every message containing them.
+const minSecretLength = 6
+
+// redactor masks secrets by value in everything the provider logs or reports.
+// Masking by value, rather than by log field key, also covers secrets ending
+// up in messages, request bodies and server errors echoing them. Tokens
+// obtained after configuration are added as they come.
+type redactor struct {
+	mu      sync.RWMutex
+	secrets []string
+}
+
+// Add registers secrets to mask. Values shorter than minSecretLength are
+// ignored.
+func (r *redactor) Add(secrets ...string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, secret := range secrets {
+		secret = strings.TrimSpace(secret)
+		if len(secret) < minSecretLength || r.contains(secret) {
+			continue
+		}
+		r.secrets = append(r.secrets, secret)
+	}
+
+	// Longest first, so a secret containing another one is masked as a whole
+	sort.Slice(r.secrets, func(i, j int) bool {
+		return len(r.secrets[i]) > len(r.secrets[j])
+	})
+}
+
+func (r *redactor) contains(secret string) bool {
+	for _, s := range r.secrets {
+		if s == secret {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Redact returns s with every registered secret masked.
+func (r *redactor) Redact(s string) string {
+	if r == nil {
+		return s
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, redactedPlaceholder)
+	}
+
+	// Tokens in login responses are logged before they can be registered
+	return secretJSONFields.ReplaceAllString(s, `"$1":"`+redactedPlaceholder+`"`)
+}
+
+// secretJSONFields matches the JSON fields of request and response bodies
+// that carry credentials.
+var secretJSONFields = regexp.MustCompile(`"(accessToken|token|password|oldPassword|newPassword)"\s*:\s*"(?:[^"\\]|\\.)*"`)
+
+// Context returns ctx with the registered secrets masked in the messages and
+// field values of every log entry written with it.
+func (r *redactor) Context(ctx context.Context) context.Context {
+	if r == nil {
+		return ctx
+	}
+
+	r.mu.RLock()
+	secrets := append([]string(nil), r.secrets...)
+	r.mu.RUnlock()
+
+	if len(secrets) == 0 {
+		return ctx
+	}
+
+	ctx = tflog.MaskMessageStrings(ctx, secrets...)
+	ctx = tflog.MaskAllFieldValuesStrings(ctx, secrets...)
+
+	return ctx
+}
+
+// Error returns err with the registered secrets masked in its message. The
+// original error stays reachable through errors.As and errors.Is.
+func (r *redactor) Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	message := err.Error()
+	if redacted := r.Redact(message); redacted != message {
+		return &redactedError{err: err, message: redacted}
+	}
+
+	return err
+}
+
+type redactedError struct {
+	err     error
+	message string
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
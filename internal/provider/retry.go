@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// retrySettings holds the retry related provider configuration.
+type retrySettings struct {
+	MaxRetries int
+	WaitMin    time.Duration
+	WaitMax    time.Duration
+}
+
+// retryTransport retries requests failing with transient errors, waiting with
+// exponential backoff and jitter between attempts. Idempotent requests are
+// retried on network errors and on 429, 502, 503 and 504 responses. GraphQL
+// requests are POSTs that may be mutations, so they are only retried when the
+// server cannot have processed them: on network errors and on 502 and 503.
+type retryTransport struct {
+	next       http.RoundTripper
+	settings   retrySettings
+	graphqlURL *url.URL
+}
+
+func newRetryTransport(next http.RoundTripper, settings retrySettings, apiEndpoint string) (*retryTransport, error) {
+	graphqlURL, err := url.Parse(apiEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid api_endpoint: %w", err)
+	}
+
+	return &retryTransport{
+		next:       next,
+		settings:   settings,
+		graphqlURL: graphqlURL,
+	}, nil
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		res, err := t.next.RoundTrip(req)
+		if attempt >= t.settings.MaxRetries || !t.shouldRetry(req, res, err) {
+			return res, err
+		}
+
+		// Replaying the request requires a fresh copy of its body
+		if req.Body != nil && req.GetBody == nil {
+			return res, err
+		}
+
+		wait := t.backoff(attempt, res)
+		fields := map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status_code"] = res.StatusCode
+			res.Body.Close()
+		}
+		tflog.Warn(ctx, "Retrying Litmus Chaos request after transient error", fields)
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+	}
+}
+
+func (t *retryTransport) shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if t.isGraphQL(req) {
+		return err != nil || res.StatusCode == http.StatusBadGateway || res.StatusCode == http.StatusServiceUnavailable
+	}
+
+	if !isIdempotent(req.Method) {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+func (t *retryTransport) isGraphQL(req *http.Request) bool {
+	return req.Method == http.MethodPost && req.URL.Host == t.graphqlURL.Host && req.URL.Path == t.graphqlURL.Path
+}
+
+// backoff returns how long to wait before the next attempt. A Retry-After
+// header takes precedence when it asks for a longer wait, up to WaitMax so that
+// a misbehaving server cannot stall the provider.
+func (t *retryTransport) backoff(attempt int, res *http.Response) time.Duration {
+	wait := t.settings.WaitMin << attempt
+	if wait > t.settings.WaitMax || wait <= 0 {
+		wait = t.settings.WaitMax
+	}
+
+	// Full jitter on the upper half, so concurrent clients spread out
+	if half := int64(wait / 2); half > 0 {
+		wait = time.Duration(half + rand.Int63n(half+1)) //nolint:gosec // jitter does not need a secure source
+	}
+
+	if res != nil {
+		if retryAfter := parseRetryAfter(res.Header.Get("Retry-After")); retryAfter > wait {
+			wait = min(retryAfter, t.settings.WaitMax)
+		}
+	}
+
+	return wait
+}
+
+// parseRetryAfter understands both forms of the Retry-After header: a number
+// of seconds and an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+
+	return 0
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
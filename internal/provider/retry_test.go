@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/williamokano/terraform-provider-litmus-chaos/internal/fakelitmus"
+)
+
+func TestRetryTransport(t *testing.T) {
+	testCases := map[string]struct {
+		method           string
+		path             string
+		status           int
+		expectedAttempts int
+	}{
+		"idempotent request on 503":   {method: http.MethodGet, path: "/auth/get_project/p1", status: http.StatusServiceUnavailable, expectedAttempts: 3},
+		"idempotent request on 429":   {method: http.MethodGet, path: "/auth/users", status: http.StatusTooManyRequests, expectedAttempts: 3},
+		"idempotent request on 500":   {method: http.MethodGet, path: "/auth/users", status: http.StatusInternalServerError, expectedAttempts: 1},
+		"graphql request on 502":      {method: http.MethodPost, path: "/api/query", status: http.StatusBadGateway, expectedAttempts: 3},
+		"graphql request on 504":      {method: http.MethodPost, path: "/api/query", status: http.StatusGatewayTimeout, expectedAttempts: 1},
+		"non idempotent rest request": {method: http.MethodPost, path: "/auth/create_project", status: http.StatusServiceUnavailable, expectedAttempts: 1},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				body, _ := io.ReadAll(r.Body)
+				if r.Method == http.MethodPost && string(body) != `{"query":"{}"}` {
+					t.Errorf("unexpected body on attempt %d: %q", attempts, body)
+				}
+				if attempts < 3 {
+					w.WriteHeader(testCase.status)
+				}
+			}))
+			defer server.Close()
+
+			transport, err := newRetryTransport(http.DefaultTransport, retrySettings{
+				MaxRetries: 5,
+				WaitMin:    time.Millisecond,
+				WaitMax:    5 * time.Millisecond,
+			}, server.URL+"/api/query")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			req, _ := http.NewRequest(testCase.method, server.URL+testCase.path, nil)
+			if testCase.method == http.MethodPost {
+				req, _ = http.NewRequest(testCase.method, server.URL+testCase.path, strings.NewReader(`{"query":"{}"}`))
+			}
+
+			res, err := (&http.Client{Transport: transport}).Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			res.Body.Close()
+
+			if attempts != testCase.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", testCase.expectedAttempts, attempts)
+			}
+		})
+	}
+}
+
+func TestRetryTransportGivesUp(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	transport, _ := newRetryTransport(http.DefaultTransport, retrySettings{
+		MaxRetries: 2,
+		WaitMin:    time.Millisecond,
+		WaitMax:    time.Millisecond,
+	}, server.URL+"/api/query")
+
+	res, err := (&http.Client{Transport: transport}).Get(server.URL + "/auth/users")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	if attempts != 3 || res.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected 3 attempts ending in 503, got %d attempts ending in %d", attempts, res.StatusCode)
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	transport, _ := newRetryTransport(http.DefaultTransport, retrySettings{
+		MaxRetries: 2,
+		WaitMin:    time.Second,
+		WaitMax:    10 * time.Second,
+	}, "http://localhost/api/query")
+
+	testCases := map[string]struct {
+		retryAfter string
+		expected   time.Duration
+	}{
+		"shorter": {
+			retryAfter: "1",
+			expected:   time.Second,
+		},
+		"longer": {
+			retryAfter: "5",
+			expected:   5 * time.Second,
+		},
+		"capped": {
+			retryAfter: "3600",
+			expected:   10 * time.Second,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{"Retry-After": []string{testCase.retryAfter}}}
+
+			// The first backoff is between 500ms and 1s
+			actual := transport.backoff(0, res)
+			if actual < testCase.expected/2 || actual > testCase.expected {
+				t.Errorf("expected a wait up to %s, got %s", testCase.expected, actual)
+			}
+			if testCase.expected > time.Second && actual != testCase.expected {
+				t.Errorf("expected a wait of %s, got %s", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestConfigureRetryWaits(t *testing.T) {
+	server := fakelitmus.NewServer()
+	defer server.Close()
+
+	testCases := map[string]struct {
+		retryWaitMin  string
+		retryWaitMax  string
+		expectedError string
+	}{
+		"valid": {
+			retryWaitMin: "500ms",
+			retryWaitMax: "10s",
+		},
+		"zero minimum": {
+			retryWaitMin:  "0s",
+			expectedError: "retry_wait_min must be a positive duration such as 500ms or 10s, got: 0s",
+		},
+		"zero maximum": {
+			retryWaitMin:  "1ms",
+			retryWaitMax:  "0",
+			expectedError: "retry_wait_max must be a positive duration such as 500ms or 10s, got: 0",
+		},
+		"negative minimum": {
+			retryWaitMin:  "-1s",
+			expectedError: "retry_wait_min must be a positive duration such as 500ms or 10s, got: -1s",
+		},
+		"minimum above maximum": {
+			retryWaitMin:  "1m",
+			retryWaitMax:  "1s",
+			expectedError: "retry_wait_min cannot be greater than retry_wait_max.",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			values := map[string]tftypes.Value{
+				"host":                  tftypes.NewValue(tftypes.String, server.URL),
+				"token":                 tftypes.NewValue(tftypes.String, server.Token()),
+				"validate_on_configure": tftypes.NewValue(tftypes.Bool, false),
+				"retry_wait_min":        tftypes.NewValue(tftypes.String, testCase.retryWaitMin),
+			}
+			if testCase.retryWaitMax != "" {
+				values["retry_wait_max"] = tftypes.NewValue(tftypes.String, testCase.retryWaitMax)
+			}
+
+			resp := testConfigureProvider(context.Background(), t, values)
+
+			testCheckDiagnostics(t, resp.Diagnostics, testCase.expectedError)
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if actual := parseRetryAfter("7"); actual != 7*time.Second {
+		t.Errorf("expected 7s, got %s", actual)
+	}
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if actual := parseRetryAfter(date); actual < 55*time.Second || actual > time.Minute {
+		t.Errorf("expected about a minute, got %s", actual)
+	}
+
+	if actual := parseRetryAfter("soon"); actual != 0 {
+		t.Errorf("expected 0, got %s", actual)
+	}
+}
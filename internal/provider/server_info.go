@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// Capabilities that differ between Litmus Chaos server versions.
+const (
+	capabilityEnvironments    = "environments"
+	capabilityProbes          = "probes"
+	capabilityGitOps          = "gitops"
+	capabilityProjectDeletion = "project_deletion"
+)
+
+// capabilityConstraints maps each capability to the server versions providing it.
+var capabilityConstraints = map[string]version.Constraints{
+	capabilityEnvironments:    version.MustConstraints(version.NewConstraint(">= 3.0.0")),
+	capabilityProbes:          version.MustConstraints(version.NewConstraint(">= 3.0.0")),
+	capabilityGitOps:          version.MustConstraints(version.NewConstraint(">= 2.0.0")),
+	capabilityProjectDeletion: version.MustConstraints(version.NewConstraint(">= 3.9.0")),
+}
+
+// serverInfo describes the Litmus Chaos control plane the provider talks to.
+type serverInfo struct {
+	Version      string
+	AuthVersion  string
+	Ready        bool
+	Capabilities map[string]bool
+}
+
+// Supports reports whether the server provides capability.
+func (i *serverInfo) Supports(capability string) bool {
+	return i.Capabilities[capability]
+}
+
+// capabilitiesForVersion derives the capabilities of a server version. Versions
+// that cannot be parsed, such as development builds, are assumed to be recent
+// and to support everything.
+func capabilitiesForVersion(serverVersion string) map[string]bool {
+	capabilities := make(map[string]bool, len(capabilityConstraints))
+
+	parsed, err := version.NewVersion(strings.TrimPrefix(serverVersion, "v"))
+	for capability, constraints := range capabilityConstraints {
+		capabilities[capability] = err != nil || constraints.Check(parsed.Core())
+	}
+
+	return capabilities
+}
+
+// ServerInfo queries the version and readiness of the control plane. The
+// result is cached for the lifetime of the client.
+func (c *apiClient) ServerInfo(ctx context.Context) (*serverInfo, error) {
+	c.serverInfoMu.Lock()
+	defer c.serverInfoMu.Unlock()
+
+	if c.serverInfo != nil {
+		return c.serverInfo, nil
+	}
+
+	serverVersion, err := c.serverVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &serverInfo{
+		Version:      serverVersion,
+		AuthVersion:  c.authServerVersion(ctx),
+		Ready:        c.isReady(ctx, c.authEndpoint+"/readiness") && c.isReady(ctx, apiReadinessURL(c.apiEndpoint)),
+		Capabilities: capabilitiesForVersion(serverVersion),
+	}
+
+	c.serverInfo = info
+
+	return info, nil
+}
+
+// serverVersion reads the GraphQL server version, falling back to the query
+// served by 2.x servers.
+func (c *apiClient) serverVersion(ctx context.Context) (string, error) {
+	type versionResponse struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}
+
+	var v3 struct {
+		GetServerVersion versionResponse `json:"getServerVersion"`
+	}
+	err := c.graphql(ctx, `query { getServerVersion { key value } }`, nil, &v3)
+	if err == nil {
+		return v3.GetServerVersion.Value, nil
+	}
+
+	var v2 struct {
+		GetVersionDetails versionResponse `json:"getVersionDetails"`
+	}
+	if err2 := c.graphql(ctx, `query { getVersionDetails { key value } }`, nil, &v2); err2 == nil {
+		return v2.GetVersionDetails.Value, nil
+	}
+
+	return "", fmt.Errorf("failed to get server version: %w", err)
+}
+
+// authServerVersion reads the version the authentication server reports on its
+// status endpoint. Not every server reports one, so failures yield "".
+func (c *apiClient) authServerVersion(ctx context.Context) string {
+	var status struct {
+		Version string `json:"version"`
+	}
+	if err := doJSON(ctx, c.httpClient, http.MethodGet, c.authEndpoint+"/status", "", nil, &status); err != nil {
+		return ""
+	}
+
+	return status.Version
+}
+
+func (c *apiClient) isReady(ctx context.Context, url string) bool {
+	return doJSON(ctx, c.httpClient, http.MethodGet, url, "", nil, nil) == nil
+}
+
+// apiReadinessURL returns the readiness endpoint of the GraphQL server, served
+// next to its query endpoint.
+func apiReadinessURL(apiEndpoint string) string {
+	endpoint, err := url.Parse(apiEndpoint)
+	if err != nil {
+		return apiEndpoint
+	}
+
+	endpoint.Path = path.Join(path.Dir(endpoint.Path), "readiness")
+
+	return endpoint.String()
+}
+
+// unsupportedDetail is the diagnostic detail for a feature the server lacks.
+func unsupportedDetail(info *serverInfo, feature string) string {
+	return fmt.Sprintf("%s is not supported on Litmus Chaos server version %q.", feature, info.Version)
+}
+
+// requireCapability returns an error diagnostic unless the server provides
+// capability, feature naming it for users, e.g. "Environment management".
+func requireCapability(ctx context.Context, api litmusAPI, capability string, feature string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	info, err := api.ServerInfo(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error Discovering Litmus Chaos Server Version",
+			"Could not check that the server supports "+strings.ToLower(feature)+": "+err.Error(),
+		)
+		return diags
+	}
+
+	if !info.Supports(capability) {
+		diags.AddError("Unsupported Litmus Chaos Server Version", unsupportedDetail(info, feature))
+	}
+
+	return diags
+}
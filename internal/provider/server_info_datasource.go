@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &serverInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &serverInfoDataSource{}
+)
+
+type serverInfoDataSourceModel struct {
+	ServerVersion     types.String `tfsdk:"server_version"`
+	AuthServerVersion types.String `tfsdk:"auth_server_version"`
+	Ready             types.Bool   `tfsdk:"ready"`
+	Capabilities      types.Map    `tfsdk:"capabilities"`
+}
+
+type serverInfoDataSource struct {
+	client litmusAPI
+}
+
+func NewServerInfoDataSource() datasource.DataSource {
+	return &serverInfoDataSource{}
+}
+
+func (d *serverInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data, diags := providerDataFrom(req.ProviderData, "Data Source")
+	resp.Diagnostics.Append(diags...)
+	if data == nil {
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *serverInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_info"
+}
+
+func (d *serverInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Version, readiness and capabilities of the Litmus Chaos control plane.",
+		Attributes: map[string]schema.Attribute{
+			"server_version": schema.StringAttribute{
+				Description: "Version of the GraphQL server",
+				Computed:    true,
+			},
+			"auth_server_version": schema.StringAttribute{
+				Description: "Version of the authentication server. Empty if the server does not report it.",
+				Computed:    true,
+			},
+			"ready": schema.BoolAttribute{
+				Description: "Whether both the authentication and the GraphQL servers report being ready",
+				Computed:    true,
+			},
+			"capabilities": schema.MapAttribute{
+				Description: "Features supported by the server version: `environments`, `probes`, `gitops` and `project_deletion`",
+				ElementType: types.BoolType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *serverInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state serverInfoDataSourceModel
+
+	info, err := d.client.ServerInfo(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Litmus Chaos Server Info",
+			"Could not read Litmus Chaos server info: "+err.Error(),
+		)
+		return
+	}
+
+	state.ServerVersion = types.StringValue(info.Version)
+	state.AuthServerVersion = types.StringValue(info.AuthVersion)
+	state.Ready = types.BoolValue(info.Ready)
+
+	capabilities, diags := types.MapValueFrom(ctx, types.BoolType, info.Capabilities)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Capabilities = capabilities
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,54 @@
+package provider
+
+import "testing"
+
+func TestCapabilitiesForVersion(t *testing.T) {
+	testCases := map[string]map[string]bool{
+		"2.14.0": {
+			capabilityEnvironments:    false,
+			capabilityProbes:          false,
+			capabilityGitOps:          true,
+			capabilityProjectDeletion: false,
+		},
+		"3.0.0-beta1": {
+			capabilityEnvironments:    true,
+			capabilityProbes:          true,
+			capabilityGitOps:          true,
+			capabilityProjectDeletion: false,
+		},
+		"v3.9.1": {
+			capabilityEnvironments:    true,
+			capabilityProbes:          true,
+			capabilityGitOps:          true,
+			capabilityProjectDeletion: true,
+		},
+		"ci": {
+			capabilityEnvironments:    true,
+			capabilityProbes:          true,
+			capabilityGitOps:          true,
+			capabilityProjectDeletion: true,
+		},
+	}
+
+	for serverVersion, expected := range testCases {
+		t.Run(serverVersion, func(t *testing.T) {
+			capabilities := capabilitiesForVersion(serverVersion)
+			for capability, supported := range expected {
+				if capabilities[capability] != supported {
+					t.Errorf("expected %s to be %t, got %t", capability, supported, capabilities[capability])
+				}
+			}
+		})
+	}
+}
+
+func TestAPIReadinessURL(t *testing.T) {
+	for apiEndpoint, expected := range map[string]string{
+		"https://litmus.example.com/api/query": "https://litmus.example.com/api/readiness",
+		"https://litmus-api.example.com/query": "https://litmus-api.example.com/readiness",
+	} {
+		if actual := apiReadinessURL(apiEndpoint); actual != expected {
+			t.Errorf("expected %s, got %s", expected, actual)
+		}
+	}
+}
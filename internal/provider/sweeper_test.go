@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/williamokano/terraform-provider-litmus-chaos/internal/fakelitmus"
+)
+
+// testAccPrefix starts the name of every object created by acceptance tests,
+// so that sweepers can tell leaked ones apart.
+const testAccPrefix = "tf-acc-"
+
+// testSweptPrefix is prepended to the name of the leaked projects by
+// sweepers, so they are not swept again and their names are free for later
+// runs.
+const testSweptPrefix = "swept-"
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("litmus-chaos_project", &resource.Sweeper{
+		Name: "litmus-chaos_project",
+		F:    sweepProjects,
+	})
+}
+
+// sweepProjects renames the projects leaked by acceptance tests, as projects
+// cannot be deleted.
+func sweepProjects(_ string) error {
+	ctx := context.Background()
+
+	data, err := configureFromEnvironment(ctx, "test")
+	if err != nil {
+		return err
+	}
+	api := data.client
+
+	projects, err := api.ListProjects(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, project := range projects {
+		if !strings.HasPrefix(project.Name, testAccPrefix) {
+			continue
+		}
+
+		log.Printf("[INFO] Renaming project %s (%s)", project.Name, project.ID)
+		if _, err := api.UpdateProjectName(ctx, project.ID, testSweptPrefix+project.Name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func TestSweepProjects(t *testing.T) {
+	testCases := map[string]struct {
+		projects      []string
+		operation     string
+		expected      []string
+		expectedSwept int
+		expectedError string
+	}{
+		"leaked": {
+			projects:      []string{"production", "tf-acc-leaked"},
+			expected:      []string{"production", "swept-tf-acc-leaked"},
+			expectedSwept: 1,
+		},
+		"not prefixed": {
+			projects: []string{"my-tf-acc-project", "production"},
+			expected: []string{"my-tf-acc-project", "production"},
+		},
+		"list error": {
+			projects:      []string{"tf-acc-leaked"},
+			operation:     "list_projects",
+			expected:      []string{"tf-acc-leaked"},
+			expectedError: "got 403",
+		},
+		"rename error": {
+			projects:      []string{"tf-acc-leaked", "tf-acc-other"},
+			operation:     "update_project_name",
+			expectedSwept: 1,
+			expectedError: "got 403",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := fakelitmus.NewServer()
+			defer server.Close()
+
+			t.Setenv("LITMUS_CHAOS_HOST", server.URL)
+			t.Setenv("LITMUS_CHAOS_TOKEN", "")
+			t.Setenv("LITMUS_CHAOS_USERNAME", fakelitmus.AdminUsername)
+			t.Setenv("LITMUS_CHAOS_PASSWORD", fakelitmus.AdminPassword)
+
+			for _, project := range testCase.projects {
+				server.AddProject(project)
+			}
+
+			if testCase.operation != "" {
+				server.InjectFault(testCase.operation, fakelitmus.Fault{StatusCode: http.StatusForbidden})
+			}
+
+			err := sweepProjects("")
+			switch {
+			case testCase.expectedError == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case testCase.expectedError != "" && (err == nil || !strings.Contains(err.Error(), testCase.expectedError)):
+				t.Fatalf("expected error containing %q, got %v", testCase.expectedError, err)
+			}
+
+			var actual []string
+			swept := 0
+			for _, project := range server.Projects() {
+				actual = append(actual, project.Name)
+				if strings.HasPrefix(project.Name, testSweptPrefix+testAccPrefix) {
+					swept++
+				}
+			}
+			sort.Strings(actual)
+
+			if swept != testCase.expectedSwept {
+				t.Errorf("expected %d projects to be swept, got %q", testCase.expectedSwept, actual)
+			}
+
+			// Which of the renames fails depends on the listing order
+			if testCase.expected == nil {
+				return
+			}
+
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("expected projects %q, got %q", testCase.expected, actual)
+			}
+		})
+	}
+}
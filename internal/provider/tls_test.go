@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTLSSettingsCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caCertPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	testCases := map[string]struct {
+		settings    tlsSettings
+		expectError bool
+	}{
+		"system roots only": {settings: tlsSettings{}, expectError: true},
+		"custom ca":         {settings: tlsSettings{CACertPEM: caCertPEM}},
+		"skip verify":       {settings: tlsSettings{InsecureSkipVerify: true}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tlsConfig, err := testCase.settings.tlsConfig()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			httpClient := &http.Client{Transport: newTransport(tlsConfig, nil, nil)}
+			res, err := httpClient.Get(server.URL)
+			if err == nil {
+				res.Body.Close()
+			}
+
+			if testCase.expectError != (err != nil) {
+				t.Errorf("expected error: %t, got: %v", testCase.expectError, err)
+			}
+		})
+	}
+}
+
+func TestTLSSettingsInvalid(t *testing.T) {
+	for name, settings := range map[string]tlsSettings{
+		"invalid ca pem":   {CACertPEM: "not a certificate"},
+		"missing ca file":  {CACertFile: "testdata/does-not-exist.pem"},
+		"cert without key": {ClientCertPEM: "cert"},
+		"invalid key pair": {ClientCertPEM: "cert", ClientKeyPEM: "key"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := settings.tlsConfig(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// tokenClaims are the claims Litmus Chaos puts into the JWTs it issues, both
+// on login and for API tokens.
+type tokenClaims struct {
+	UserID    string `json:"uid"`
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Expiry returns when the token expires, or the zero time if it never does.
+func (c tokenClaims) Expiry() time.Time {
+	if c.ExpiresAt == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(c.ExpiresAt, 0).UTC()
+}
+
+// parseTokenClaims decodes the claims of a Litmus Chaos JWT. The signature is
+// not verified, that is up to the server.
+func parseTokenClaims(token string) (*tokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode token payload: %w", err)
+	}
+
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to decode token claims: %w", err)
+	}
+
+	if claims.UserID == "" {
+		return nil, errors.New("token does not identify a user")
+	}
+
+	return &claims, nil
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &tokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &tokenEphemeralResource{}
+)
+
+type tokenEphemeralResourceModel struct {
+	Token     types.String `tfsdk:"token"`
+	UserID    types.String `tfsdk:"user_id"`
+	Username  types.String `tfsdk:"username"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+// tokenEphemeralResource issues access tokens for other providers, such as
+// the http or kubernetes ones, which Terraform never persists in plan or state.
+type tokenEphemeralResource struct {
+	client litmusAPI
+}
+
+func NewTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &tokenEphemeralResource{}
+}
+
+func (r *tokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	data, diags := providerDataFrom(req.ProviderData, "Ephemeral Resource")
+	resp.Diagnostics.Append(diags...)
+	if data == nil {
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *tokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_token"
+}
+
+func (r *tokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Short-lived access token to the Litmus Chaos API, obtained by logging in with the username and password of the provider. " +
+			"As an ephemeral resource, the token is never stored in the Terraform plan or state. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"token": schema.StringAttribute{
+				Description: "Access token",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"user_id": schema.StringAttribute{
+				Description: "ID of the user the token authenticates as",
+				Computed:    true,
+			},
+			"username": schema.StringAttribute{
+				Description: "Username of the user the token authenticates as",
+				Computed:    true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "Expiry of the token, in RFC 3339 format. Empty if the token does not expire.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *tokenEphemeralResource) Open(ctx context.Context, _ ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	token, err := r.client.IssueToken(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error issuing Litmus Chaos Token",
+			"Could not log in to issue a token: "+err.Error(),
+		)
+		return
+	}
+
+	claims, err := parseTokenClaims(token)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error issuing Litmus Chaos Token",
+			"Could not decode the issued token: "+err.Error(),
+		)
+		return
+	}
+
+	result := tokenEphemeralResourceModel{
+		Token:     types.StringValue(token),
+		UserID:    types.StringValue(claims.UserID),
+		Username:  types.StringValue(claims.Username),
+		ExpiresAt: types.StringValue(""),
+	}
+
+	if expiry := claims.Expiry(); !expiry.IsZero() {
+		result.ExpiresAt = types.StringValue(expiry.Format(time.RFC3339))
+	}
+
+	diags := resp.Result.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+}
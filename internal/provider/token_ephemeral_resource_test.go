@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/williamokano/terraform-provider-litmus-chaos/internal/fakelitmus"
+)
+
+func TestAccTokenEphemeralResource(t *testing.T) {
+	testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"litmus-chaos": testAccProtoV6ProviderFactories["litmus-chaos"],
+			"echo":         echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+ephemeral "litmus-chaos_token" "current" {}
+
+provider "echo" {
+  data = ephemeral.litmus-chaos_token.current
+}
+
+resource "echo" "token" {}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.token", tfjsonpath.New("data").AtMapKey("token"), knownvalue.StringRegexp(regexp.MustCompile(`^[\w-]+\.[\w-]+\.[\w-]+$`))),
+					statecheck.ExpectKnownValue("echo.token", tfjsonpath.New("data").AtMapKey("username"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("echo.token", tfjsonpath.New("data").AtMapKey("expires_at"), knownvalue.NotNull()),
+				},
+			},
+		},
+	})
+}
+
+func TestTokenEphemeralResourceOpen(t *testing.T) {
+	expiry := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	token := testToken(fmt.Sprintf(`{"uid":"b1c2","username":"ci-bot","role":"user","exp":%d}`, expiry.Unix()))
+
+	testCases := map[string]struct {
+		api            mockAPI
+		expectedResult map[string]string
+		expectedError  string
+	}{
+		"issued": {
+			api: mockAPI{
+				issueToken: func(context.Context) (string, error) {
+					return token, nil
+				},
+			},
+			expectedResult: map[string]string{
+				"token":      token,
+				"user_id":    "b1c2",
+				"username":   "ci-bot",
+				"expires_at": "2030-01-02T03:04:05Z",
+			},
+		},
+		"without expiry": {
+			api: mockAPI{
+				issueToken: func(context.Context) (string, error) {
+					return testToken(`{"uid":"b1c2","username":"ci-bot","role":"user"}`), nil
+				},
+			},
+			expectedResult: map[string]string{"expires_at": ""},
+		},
+		"login error": {
+			api: mockAPI{
+				issueToken: func(context.Context) (string, error) {
+					return "", errors.New("failed to login as ci-bot: invalid credentials")
+				},
+			},
+			expectedError: "Could not log in to issue a token: failed to login as ci-bot: invalid credentials",
+		},
+		"not a JWT": {
+			api: mockAPI{
+				issueToken: func(context.Context) (string, error) {
+					return "opaque", nil
+				},
+			},
+			expectedError: "Could not decode the issued token: token is not a JWT",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &tokenEphemeralResource{client: &testCase.api}
+
+			schemaResp := &ephemeral.SchemaResponse{}
+			r.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+			s := schemaResp.Schema
+
+			resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}}
+			r.Open(ctx, ephemeral.OpenRequest{
+				Config: tfsdk.Config{Schema: s, Raw: testObject(t, s.Type().TerraformType(ctx), nil)},
+			}, resp)
+
+			testCheckDiagnostics(t, resp.Diagnostics, testCase.expectedError)
+			if testCase.expectedError != "" {
+				return
+			}
+
+			for name, expected := range testCase.expectedResult {
+				var actual types.String
+				resp.Result.GetAttribute(ctx, path.Root(name), &actual)
+				if actual.ValueString() != expected {
+					t.Errorf("expected %s to be %q, got %s", name, expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestIssueToken(t *testing.T) {
+	server := fakelitmus.NewServer()
+	defer server.Close()
+
+	for _, name := range []string{"LITMUS_CHAOS_TOKEN", "LITMUS_CHAOS_TOKEN_FILE", "LITMUS_CHAOS_USERNAME", "LITMUS_CHAOS_PASSWORD", "LITMUS_CHAOS_PROXY_URL"} {
+		t.Setenv(name, "")
+	}
+
+	testCases := map[string]struct {
+		values        map[string]tftypes.Value
+		expectedError string
+	}{
+		"username and password": {
+			values: map[string]tftypes.Value{
+				"username": tftypes.NewValue(tftypes.String, fakelitmus.AdminUsername),
+				"password": tftypes.NewValue(tftypes.String, fakelitmus.AdminPassword),
+			},
+		},
+		"token": {
+			values: map[string]tftypes.Value{
+				"token": tftypes.NewValue(tftypes.String, server.Token()),
+			},
+			expectedError: "issuing tokens requires the provider to be configured with a username and password",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			testCase.values["host"] = tftypes.NewValue(tftypes.String, server.URL)
+			resp := testConfigureProvider(ctx, t, testCase.values)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			api := resp.EphemeralResourceData.(*providerData).client //nolint:forcetypeassert // checked by Configure
+
+			current, err := api.CurrentUser(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			issued, err := api.IssueToken(ctx)
+			if testCase.expectedError != "" {
+				if err == nil || err.Error() != testCase.expectedError {
+					t.Errorf("expected error %q, got %v", testCase.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			claims, err := parseTokenClaims(issued)
+			if err != nil || claims.UserID != current.UserID {
+				t.Errorf("expected a token of user %s, got %+v (%v)", current.UserID, claims, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// tokenExpiryLeeway is how long before its expiry a token obtained by logging
+// in is already refreshed, so that it does not expire mid request.
+const tokenExpiryLeeway = 30 * time.Second
+
+// tokenSource hands out the token requests are authenticated with. Tokens
+// that can be obtained again, by logging in or from a credential helper, are
+// refreshed both shortly before they expire and when the server rejects them.
+// Static API tokens are used as they are.
+type tokenSource struct {
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+
+	// refresh obtains a new token and its expiry, nil for static tokens.
+	refresh func(ctx context.Context) (string, time.Time, error)
+
+	// redactor is told about every new token, so that it is masked in logs.
+	redactor *redactor
+}
+
+// staticTokenSource returns a token source that never refreshes token.
+func staticTokenSource(token string) *tokenSource {
+	return &tokenSource{token: token}
+}
+
+// loginTokenSource returns a token source logging in with username and
+// password whenever a new token is needed.
+func loginTokenSource(httpClient *http.Client, authEndpoint string, username string, password string) *tokenSource {
+	return &tokenSource{
+		refresh: func(ctx context.Context) (string, time.Time, error) {
+			token, err := login(ctx, httpClient, authEndpoint, username, password)
+			if err != nil {
+				return "", time.Time{}, err
+			}
+
+			return token, tokenExpiry(token), nil
+		},
+	}
+}
+
+// canRefresh reports whether the source is able to obtain a new token.
+func (s *tokenSource) canRefresh() bool {
+	return s.refresh != nil
+}
+
+// Token returns the current token, obtaining one first when there is none yet
+// or when it is about to expire.
+func (s *tokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.canRefresh() {
+		return s.token, nil
+	}
+
+	if s.token != "" && (s.expiry.IsZero() || time.Until(s.expiry) >= tokenExpiryLeeway) {
+		return s.token, nil
+	}
+
+	return s.fetch(ctx, "token expired")
+}
+
+// Refresh obtains a new token after the server rejected stale. When another
+// request already replaced stale in the meantime, the newer token is returned
+// as is.
+func (s *tokenSource) Refresh(ctx context.Context, stale string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.canRefresh() {
+		return "", errors.New("the token cannot be refreshed without a username and password or a credential helper")
+	}
+
+	if s.token != stale {
+		return s.token, nil
+	}
+
+	return s.fetch(ctx, "token rejected by the server")
+}
+
+func (s *tokenSource) fetch(ctx context.Context, reason string) (string, error) {
+	refreshing := s.token != ""
+	if refreshing {
+		tflog.Info(ctx, "Refreshing Litmus Chaos token", map[string]any{
+			"reason": reason,
+		})
+	}
+
+	token, expiry, err := s.refresh(ctx)
+	if err != nil {
+		if refreshing {
+			return "", fmt.Errorf("failed to refresh token: %w", err)
+		}
+		return "", err
+	}
+	s.redactor.Add(token)
+	s.token = token
+	s.expiry = expiry
+
+	return token, nil
+}
+
+// tokenExpiry returns the expiry of token, or the zero time when it cannot be
+// decoded or carries no expiry. Such tokens are assumed to stay valid, the
+// server has the last word on them.
+func tokenExpiry(token string) time.Time {
+	claims, err := parseTokenClaims(token)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return claims.Expiry()
+}
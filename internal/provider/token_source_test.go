@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTokenRefresh(t *testing.T) {
+	expired := testToken(fmt.Sprintf(`{"uid":"b1c2","username":"admin","exp":%d}`, time.Now().Add(-time.Minute).Unix()))
+	fresh := testToken(fmt.Sprintf(`{"uid":"b1c2","username":"admin","exp":%d}`, time.Now().Add(time.Hour).Unix()))
+	// Revoked server side although not expired yet
+	revoked := testToken(fmt.Sprintf(`{"uid":"b1c2","username":"admin","exp":%d}`, time.Now().Add(time.Hour).Unix()+1))
+
+	testCases := map[string]struct {
+		token         string
+		expectedLogin int
+	}{
+		"valid token":   {token: fresh, expectedLogin: 0},
+		"expired token": {token: expired, expectedLogin: 1},
+		"revoked token": {token: revoked, expectedLogin: 1},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			logins := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/auth/login":
+					logins++
+					fmt.Fprintf(w, `{"accessToken":%q}`, fresh)
+				case r.Header.Get("Authorization") != "Bearer "+fresh:
+					w.WriteHeader(http.StatusUnauthorized)
+				default:
+					fmt.Fprint(w, `[]`)
+				}
+			}))
+			defer server.Close()
+
+			tokens := loginTokenSource(server.Client(), defaultAuthEndpoint(server.URL), "admin", "litmus")
+			tokens.token = testCase.token
+			tokens.expiry = tokenExpiry(testCase.token)
+
+			client := &apiClient{
+				authEndpoint: defaultAuthEndpoint(server.URL),
+				httpClient:   server.Client(),
+				tokens:       tokens,
+			}
+
+			if _, err := client.FetchUsers(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if logins != testCase.expectedLogin {
+				t.Errorf("expected %d logins, got %d", testCase.expectedLogin, logins)
+			}
+		})
+	}
+}
+
+func TestStaticTokenIsNotRefreshed(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := &apiClient{
+		authEndpoint: defaultAuthEndpoint(server.URL),
+		httpClient:   server.Client(),
+		tokens:       staticTokenSource("token"),
+	}
+
+	if _, err := client.FetchUsers(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if requests != 1 {
+		t.Errorf("expected a single request, got %d", requests)
+	}
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func testToken(payload string) string {
+	return "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+		base64.RawURLEncoding.EncodeToString([]byte(payload)) +
+		".c2lnbmF0dXJl"
+}
+
+func TestParseTokenClaims(t *testing.T) {
+	claims, err := parseTokenClaims(testToken(`{"uid":"b1c2","username":"admin","role":"admin","exp":1700000000}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if claims.UserID != "b1c2" || claims.Username != "admin" || claims.Role != "admin" {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+
+	if want := time.Unix(1700000000, 0).UTC(); !claims.Expiry().Equal(want) {
+		t.Errorf("expected expiry %s, got %s", want, claims.Expiry())
+	}
+}
+
+func TestParseTokenClaimsErrors(t *testing.T) {
+	for name, token := range map[string]string{
+		"not a jwt":   "veryfaketoken",
+		"bad payload": "a.%%%.c",
+		"no user":     testToken(`{"username":"admin"}`),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseTokenClaims(token); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// proxySettings holds the proxy related provider configuration.
+type proxySettings struct {
+	ProxyURL string
+	NoProxy  string
+}
+
+// proxyFunc returns the proxy selection used by the transport. Without an
+// explicit proxy_url the usual HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment
+// variables apply, with no_proxy overriding NO_PROXY when set.
+func (s proxySettings) proxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	config := httpproxy.FromEnvironment()
+
+	if s.ProxyURL != "" {
+		if _, err := url.Parse(s.ProxyURL); err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %w", err)
+		}
+		config.HTTPProxy = s.ProxyURL
+		config.HTTPSProxy = s.ProxyURL
+	}
+
+	if s.NoProxy != "" {
+		config.NoProxy = s.NoProxy
+	}
+
+	proxyFunc := config.ProxyFunc()
+
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
+
+// newTransport returns the HTTP transport every request to the control plane
+// goes through, both to the authentication and to the GraphQL server.
+func newTransport(tlsConfig *tls.Config, proxy func(*http.Request) (*url.URL, error), headers map[string]string) http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert // always a *http.Transport
+	transport.TLSClientConfig = tlsConfig
+
+	if proxy != nil {
+		transport.Proxy = proxy
+	}
+
+	if len(headers) == 0 {
+		return transport
+	}
+
+	return &headerTransport{
+		next:    transport,
+		headers: headers,
+	}
+}
+
+// reservedHeaders are set by the provider itself and cannot be configured
+// with the headers attribute.
+var reservedHeaders = []string{"Authorization", "Content-Type"}
+
+// headerValue looks name up in headers, ignoring case as HTTP does.
+func headerValue(headers map[string]string, name string) (string, bool) {
+	for key, value := range headers {
+		if http.CanonicalHeaderKey(key) == http.CanonicalHeaderKey(name) {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// headerTransport adds the configured headers to every request.
+type headerTransport struct {
+	next    http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the original request
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// timeoutTransport bounds every single request, including reading its
+// response body. It sits below the retry transport so that each attempt gets
+// its own budget, while cancelling the caller's context still aborts them all.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func newTimeoutTransport(next http.RoundTripper, timeout time.Duration) http.RoundTripper {
+	if timeout <= 0 {
+		return next
+	}
+
+	return &timeoutTransport{
+		next:    next,
+		timeout: timeout,
+	}
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	res, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// The context must outlive RoundTrip until the body has been read
+	res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+
+	return res, nil
+}
+
+// cancelOnClose releases the request context once the body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// loggingTransport logs every request and response, bodies included, at
+// TRACE level. Everything logged goes through the redactor first.
+type loggingTransport struct {
+	next     http.RoundTripper
+	redactor *redactor
+}
+
+func newLoggingTransport(next http.RoundTripper, redactor *redactor) http.RoundTripper {
+	return &loggingTransport{
+		next:     next,
+		redactor: redactor,
+	}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := t.redactor.Context(req.Context())
+
+	fields := map[string]any{
+		"method": req.Method,
+		"url":    t.redactor.Redact(req.URL.String()),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			content, _ := io.ReadAll(body)
+			fields["body"] = t.redactor.Redact(string(content))
+		}
+	}
+	tflog.Trace(ctx, "Sending Litmus Chaos request", fields)
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		tflog.Trace(ctx, "Litmus Chaos request failed", map[string]any{
+			"method": req.Method,
+			"url":    fields["url"],
+			"error":  t.redactor.Redact(err.Error()),
+		})
+		return nil, err
+	}
+
+	// The body is buffered to be logged and handed on as if it was untouched
+	content, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(content))
+
+	tflog.Trace(ctx, "Received Litmus Chaos response", map[string]any{
+		"method":      req.Method,
+		"url":         fields["url"],
+		"status_code": res.StatusCode,
+		"body":        t.redactor.Redact(string(content)),
+	})
+
+	return res, nil
+}
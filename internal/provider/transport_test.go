@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/williamokano/terraform-provider-litmus-chaos/internal/fakelitmus"
+)
+
+func TestTransportProxyAndHeaders(t *testing.T) {
+	var proxiedHost, routingHeader string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.Host
+		routingHeader = r.Header.Get("X-Litmus-Route")
+	}))
+	defer proxy.Close()
+
+	proxyFunc, err := proxySettings{ProxyURL: proxy.URL}.proxyFunc()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	httpClient := &http.Client{Transport: newTransport(nil, proxyFunc, map[string]string{"X-Litmus-Route": "chaos"})}
+	res, err := httpClient.Get("http://litmus.internal/auth/users")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	if proxiedHost != "litmus.internal" {
+		t.Errorf("expected request to go through the proxy, got host %q", proxiedHost)
+	}
+
+	if routingHeader != "chaos" {
+		t.Errorf("expected routing header to be set, got %q", routingHeader)
+	}
+}
+
+func TestReservedHeaders(t *testing.T) {
+	server := fakelitmus.NewServer()
+	defer server.Close()
+
+	for _, name := range []string{"LITMUS_CHAOS_TOKEN", "LITMUS_CHAOS_TOKEN_FILE", "LITMUS_CHAOS_PROXY_URL"} {
+		t.Setenv(name, "")
+	}
+
+	testCases := map[string]struct {
+		header        string
+		expectedError string
+	}{
+		"routing": {
+			header: "X-Litmus-Route",
+		},
+		"authorization": {
+			header:        "authorization",
+			expectedError: "The authorization header is set by the provider",
+		},
+		"content-type": {
+			header:        "Content-Type",
+			expectedError: "The Content-Type header is set by the provider",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := testConfigureProvider(context.Background(), t, map[string]tftypes.Value{
+				"host":     tftypes.NewValue(tftypes.String, server.URL),
+				"username": tftypes.NewValue(tftypes.String, fakelitmus.AdminUsername),
+				"password": tftypes.NewValue(tftypes.String, fakelitmus.AdminPassword),
+				"headers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+					testCase.header: tftypes.NewValue(tftypes.String, "chaos"),
+				}),
+			})
+
+			testCheckDiagnostics(t, resp.Diagnostics, testCase.expectedError)
+		})
+	}
+}
+
+func TestTransportNoProxy(t *testing.T) {
+	proxyFunc, err := proxySettings{ProxyURL: "http://proxy.internal:3128", NoProxy: "litmus.internal"}.proxyFunc()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for target, expected := range map[string]string{
+		"http://litmus.internal/auth/users": "",
+		"http://other.internal/auth/users":  "http://proxy.internal:3128",
+	} {
+		req, _ := http.NewRequest(http.MethodGet, target, nil)
+		proxyURL, err := proxyFunc(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		actual := ""
+		if proxyURL != nil {
+			actual = proxyURL.String()
+		}
+
+		if actual != expected {
+			t.Errorf("expected proxy %q for %s, got %q", expected, target, actual)
+		}
+	}
+}
+
+func TestTimeoutTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte("ok")) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newTimeoutTransport(http.DefaultTransport, 50*time.Millisecond)}
+
+	res, err := client.Get(server.URL + "/fast")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil || string(body) != "ok" {
+		t.Errorf("expected body ok, got %q (%v)", body, err)
+	}
+
+	if _, err := client.Get(server.URL + "/slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
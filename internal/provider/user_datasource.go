@@ -2,12 +2,10 @@ package provider
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/williamokano/litmus-chaos-thin-client/pkg/client"
 )
 
 var (
@@ -24,7 +22,7 @@ type userDataSourceModel struct {
 }
 
 type userDataSource struct {
-	client *client.LitmusClient
+	client litmusAPI
 }
 
 func NewUserDataSource() datasource.DataSource {
@@ -32,22 +30,13 @@ func NewUserDataSource() datasource.DataSource {
 }
 
 func (d *userDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	if req.ProviderData == nil {
-		return
-	}
-
-	litmusClient, ok := req.ProviderData.(*client.LitmusClient)
-
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *litmus.LitmusClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-
+	data, diags := providerDataFrom(req.ProviderData, "Data Source")
+	resp.Diagnostics.Append(diags...)
+	if data == nil {
 		return
 	}
 
-	d.client = litmusClient
+	d.client = data.client
 }
 
 func (d *userDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -89,7 +78,7 @@ func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	user, err := d.client.FindUserByUsername(state.Username.ValueString())
+	user, err := d.client.FindUserByUsername(ctx, state.Username.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading Litmus Chaos User",
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/williamokano/litmus-chaos-thin-client/pkg/entities"
+)
+
+func TestUserDataSourceRead(t *testing.T) {
+	testCases := map[string]struct {
+		api           mockAPI
+		expectedState map[string]string
+		expectedNull  []string
+		expectedError string
+	}{
+		"found": {
+			api: mockAPI{
+				findUserByUsername: func(_ context.Context, username string) (*entities.User, error) {
+					return &entities.User{ID: "user-id", Username: username, Name: "Jane", Email: "jane@example.com", Role: entities.RoleAdmin}, nil
+				},
+			},
+			expectedState: map[string]string{
+				"id":       "user-id",
+				"username": "jane",
+				"name":     "Jane",
+				"email":    "jane@example.com",
+				"role":     "admin",
+			},
+		},
+		"without email": {
+			api: mockAPI{
+				findUserByUsername: func(_ context.Context, username string) (*entities.User, error) {
+					return &entities.User{ID: "user-id", Username: username, Role: entities.RoleUser}, nil
+				},
+			},
+			expectedState: map[string]string{"id": "user-id", "role": "user"},
+			expectedNull:  []string{"email"},
+		},
+		"api error": {
+			api: mockAPI{
+				findUserByUsername: func(context.Context, string) (*entities.User, error) {
+					return nil, errors.New("user not found")
+				},
+			},
+			expectedError: "Could not read Litmus Chaos User with username jane: user not found",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			d := &userDataSource{client: &testCase.api}
+
+			schemaResp := &datasource.SchemaResponse{}
+			d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+			s := schemaResp.Schema
+			objectType := s.Type().TerraformType(ctx)
+
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(objectType, nil)}}
+			d.Read(ctx, datasource.ReadRequest{
+				Config: tfsdk.Config{Schema: s, Raw: testObject(t, objectType, map[string]tftypes.Value{
+					"username": tftypes.NewValue(tftypes.String, "jane"),
+				})},
+			}, resp)
+
+			testCheckDiagnostics(t, resp.Diagnostics, testCase.expectedError)
+			if testCase.expectedError != "" {
+				return
+			}
+
+			testCheckState(t, resp.State, testCase.expectedState)
+
+			for _, name := range testCase.expectedNull {
+				var value types.String
+				resp.State.GetAttribute(ctx, path.Root(name), &value)
+				if !value.IsNull() {
+					t.Errorf("expected %s to be null, got %s", name, value)
+				}
+			}
+		})
+	}
+}
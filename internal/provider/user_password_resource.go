@@ -0,0 +1,315 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &userPasswordResource{}
+	_ resource.ResourceWithConfigure      = &userPasswordResource{}
+	_ resource.ResourceWithValidateConfig = &userPasswordResource{}
+	_ resource.ResourceWithUpgradeState   = &userPasswordResource{}
+)
+
+// userPasswordResource is the resource implementation.
+type userPasswordResource struct {
+	client litmusAPI
+}
+
+// userPasswordResourceModel holds the passwords only when read from the
+// configuration, as they are write-only and never stored in plan or state.
+type userPasswordResourceModel struct {
+	ID              types.String   `tfsdk:"id"`
+	Username        types.String   `tfsdk:"username"`
+	OldPassword     types.String   `tfsdk:"old_password"`
+	NewPassword     types.String   `tfsdk:"new_password"`
+	PasswordVersion types.String   `tfsdk:"password_version"`
+	Reset           types.Bool     `tfsdk:"reset"`
+	LastUpdated     types.String   `tfsdk:"last_updated"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+// userPasswordResourceModelV0 is the model of schema version 0, which kept
+// the passwords in state.
+type userPasswordResourceModelV0 struct {
+	ID          types.String   `tfsdk:"id"`
+	Username    types.String   `tfsdk:"username"`
+	OldPassword types.String   `tfsdk:"old_password"`
+	NewPassword types.String   `tfsdk:"new_password"`
+	Reset       types.Bool     `tfsdk:"reset"`
+	LastUpdated types.String   `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewUserPasswordResource() resource.Resource {
+	return &userPasswordResource{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *userPasswordResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data, diags := providerDataFrom(req.ProviderData, "Resource")
+	resp.Diagnostics.Append(diags...)
+	if data == nil {
+		return
+	}
+
+	r.client = data.client
+}
+
+// Metadata returns the resource type name.
+func (r *userPasswordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_password"
+}
+
+// Schema defines the schema for the resource.
+func (r *userPasswordResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 1,
+		Description: "Manages the password of a Litmus Chaos user. " +
+			"Passwords are write-only, so they are never stored in the Terraform plan or state, which requires Terraform 1.11 or later. " +
+			"As Terraform cannot tell when a write-only value changes, change `password_version` to change the password again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Username whose password is managed",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "Username of the user whose password is changed",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"old_password": schema.StringAttribute{
+				Description: "Current password of the user. Required unless `reset` is enabled.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"new_password": schema.StringAttribute{
+				Description: "Password to set for the user",
+				Required:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"password_version": schema.StringAttribute{
+				Description: "Arbitrary value whose changes trigger a password change, " +
+					"with `old_password` set to the password currently set for the user.",
+				Optional: true,
+			},
+			"reset": schema.BoolAttribute{
+				Description: "Reset the password instead of changing it, which does not require the current password. " +
+					"The provider must be authenticated as an admin. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Date of last modification",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+// ValidateConfig ensures the current password is known when it is needed.
+func (r *userPasswordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config userPasswordResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Reset.ValueBool() || config.Reset.IsUnknown() || config.OldPassword.IsUnknown() {
+		return
+	}
+
+	if config.OldPassword.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("old_password"),
+			"Missing Litmus Chaos User Password",
+			"The current password is required to change the password of a user. "+
+				"Set old_password, or set reset = true to reset the password as an admin.",
+		)
+	}
+}
+
+// UpgradeState drops the passwords kept in state by schema version 0.
+func (r *userPasswordResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":           schema.StringAttribute{Computed: true},
+					"username":     schema.StringAttribute{Required: true},
+					"old_password": schema.StringAttribute{Optional: true, Sensitive: true},
+					"new_password": schema.StringAttribute{Required: true, Sensitive: true},
+					"reset":        schema.BoolAttribute{Optional: true, Computed: true},
+					"last_updated": schema.StringAttribute{Computed: true},
+				},
+				Blocks: map[string]schema.Block{
+					"timeouts": timeouts.Block(ctx, timeouts.Opts{
+						Create: true,
+						Update: true,
+					}),
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior userPasswordResourceModelV0
+				diags := req.State.Get(ctx, &prior)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				diags = resp.State.Set(ctx, userPasswordResourceModel{
+					ID:              prior.ID,
+					Username:        prior.Username,
+					OldPassword:     types.StringNull(),
+					NewPassword:     types.StringNull(),
+					PasswordVersion: types.StringNull(),
+					Reset:           prior.Reset,
+					LastUpdated:     prior.LastUpdated,
+					Timeouts:        prior.Timeouts,
+				})
+				resp.Diagnostics.Append(diags...)
+			},
+		},
+	}
+}
+
+// Create changes the password and sets the initial Terraform state.
+func (r *userPasswordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, config userPasswordResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	err := r.changePassword(ctx, plan, config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error changing Litmus Chaos User Password",
+			"Could not change password of Litmus Chaos user "+plan.Username.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = plan.Username
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the Terraform state as is, as passwords cannot be read back.
+func (r *userPasswordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state userPasswordResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update changes the password again when password_version changes.
+func (r *userPasswordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state, config userPasswordResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	plan.LastUpdated = state.LastUpdated
+
+	if !plan.PasswordVersion.Equal(state.PasswordVersion) {
+		err := r.changePassword(ctx, plan, config)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error changing Litmus Chaos User Password",
+				"Could not change password of Litmus Chaos user "+plan.Username.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the resource from the Terraform state, leaving the password as is.
+func (r *userPasswordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Warn(ctx, "DELETE only removes the password from the state, the user password is left unchanged")
+}
+
+// changePassword sets the password of the planned user, taking the write-only
+// passwords from config.
+func (r *userPasswordResource) changePassword(ctx context.Context, plan userPasswordResourceModel, config userPasswordResourceModel) error {
+	if plan.Reset.ValueBool() {
+		return r.client.ResetPassword(ctx, plan.Username.ValueString(), config.NewPassword.ValueString())
+	}
+
+	return r.client.UpdatePassword(ctx, plan.Username.ValueString(), config.OldPassword.ValueString(), config.NewPassword.ValueString())
+}
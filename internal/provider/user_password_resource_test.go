@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/williamokano/terraform-provider-litmus-chaos/internal/fakelitmus"
+)
+
+func TestAccUserPasswordResource(t *testing.T) {
+	server := testAccServer(t)
+	if server == nil {
+		t.Skip("changes passwords of users created on the fake control plane")
+	}
+	server.AddUser("tf-acc-user", "initial-password", "user")
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create testing
+			{
+				Config: providerConfig + `
+resource "litmus-chaos_user_password" "user" {
+  username     = "tf-acc-user"
+  old_password = "initial-password"
+  new_password = "first-password"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("litmus-chaos_user_password.user", "id", "tf-acc-user"),
+					resource.TestCheckResourceAttrSet("litmus-chaos_user_password.user", "last_updated"),
+					resource.TestCheckNoResourceAttr("litmus-chaos_user_password.user", "old_password"),
+					resource.TestCheckNoResourceAttr("litmus-chaos_user_password.user", "new_password"),
+					testAccCheckUserPassword(server, "tf-acc-user", "first-password"),
+				),
+			},
+			// Write-only values changing alone do not change the password
+			{
+				Config: providerConfig + `
+resource "litmus-chaos_user_password" "user" {
+  username     = "tf-acc-user"
+  old_password = "first-password"
+  new_password = "ignored-password"
+}
+`,
+				PlanOnly: true,
+			},
+			// Update testing, triggered by password_version
+			{
+				Config: providerConfig + `
+resource "litmus-chaos_user_password" "user" {
+  username         = "tf-acc-user"
+  old_password     = "first-password"
+  new_password     = "second-password"
+  password_version = "2"
+}
+`,
+				Check: testAccCheckUserPassword(server, "tf-acc-user", "second-password"),
+			},
+			// Reset testing
+			{
+				Config: providerConfig + `
+resource "litmus-chaos_user_password" "user" {
+  username         = "tf-acc-user"
+  new_password     = "reset-password"
+  password_version = "3"
+  reset            = true
+}
+`,
+				Check: testAccCheckUserPassword(server, "tf-acc-user", "reset-password"),
+			},
+		},
+	})
+}
+
+func testAccCheckUserPassword(server *fakelitmus.Server, username string, password string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		user, ok := server.User(username)
+		if !ok {
+			return fmt.Errorf("user %s not found", username)
+		}
+
+		if user.Password != password {
+			return fmt.Errorf("expected password of %s to be %q, got %q", username, password, user.Password)
+		}
+
+		return nil
+	}
+}
+
+func testUserPasswordResourceSchema(t *testing.T) rschema.Schema {
+	t.Helper()
+
+	resp := &fwresource.SchemaResponse{}
+	NewUserPasswordResource().Schema(context.Background(), fwresource.SchemaRequest{}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	return resp.Schema
+}
+
+func TestUserPasswordResourceUpdate(t *testing.T) {
+	type passwordChange struct {
+		oldPassword string
+		newPassword string
+	}
+
+	testCases := map[string]struct {
+		config         map[string]string
+		expectedChange *passwordChange
+		expectedError  string
+	}{
+		"version changed": {
+			config:         map[string]string{"old_password": "first-password", "new_password": "second-password", "password_version": "2"},
+			expectedChange: &passwordChange{oldPassword: "first-password", newPassword: "second-password"},
+		},
+		"version unchanged": {
+			config: map[string]string{"old_password": "first-password", "new_password": "second-password", "password_version": "1"},
+		},
+		"api error": {
+			config:        map[string]string{"old_password": "wrong-password", "new_password": "second-password", "password_version": "2"},
+			expectedError: "Could not change password of Litmus Chaos user tf-user: invalid credentials",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := testUserPasswordResourceSchema(t)
+
+			var change *passwordChange
+			r := &userPasswordResource{client: &mockAPI{
+				updatePassword: func(_ context.Context, username string, oldPassword string, newPassword string) error {
+					if oldPassword == "wrong-password" {
+						return errors.New("invalid credentials")
+					}
+					change = &passwordChange{oldPassword: oldPassword, newPassword: newPassword}
+					return nil
+				},
+			}}
+
+			stateValues := map[string]tftypes.Value{
+				"id":               tftypes.NewValue(tftypes.String, "tf-user"),
+				"username":         tftypes.NewValue(tftypes.String, "tf-user"),
+				"password_version": tftypes.NewValue(tftypes.String, "1"),
+				"reset":            tftypes.NewValue(tftypes.Bool, false),
+				"last_updated":     tftypes.NewValue(tftypes.String, "yesterday"),
+			}
+			planValues := map[string]tftypes.Value{
+				"password_version": tftypes.NewValue(tftypes.String, testCase.config["password_version"]),
+			}
+			configValues := map[string]tftypes.Value{}
+			for name, value := range stateValues {
+				if name != "password_version" {
+					planValues[name] = value
+				}
+			}
+			for name, value := range testCase.config {
+				configValues[name] = tftypes.NewValue(tftypes.String, value)
+			}
+			configValues["username"] = stateValues["username"]
+
+			state := tfsdk.State{Schema: s, Raw: testObject(t, s.Type().TerraformType(ctx), stateValues)}
+			resp := &fwresource.UpdateResponse{State: state}
+			r.Update(ctx, fwresource.UpdateRequest{
+				Config: tfsdk.Config{Schema: s, Raw: testObject(t, s.Type().TerraformType(ctx), configValues)},
+				Plan:   tfsdk.Plan{Schema: s, Raw: testObject(t, s.Type().TerraformType(ctx), planValues)},
+				State:  state,
+			}, resp)
+
+			testCheckDiagnostics(t, resp.Diagnostics, testCase.expectedError)
+			if testCase.expectedError != "" {
+				return
+			}
+
+			if !reflect.DeepEqual(change, testCase.expectedChange) {
+				t.Errorf("expected password change %+v, got %+v", testCase.expectedChange, change)
+			}
+
+			var newPassword types.String
+			resp.State.GetAttribute(ctx, path.Root("new_password"), &newPassword)
+			if !newPassword.IsNull() {
+				t.Errorf("expected new_password not to be stored, got %s", newPassword)
+			}
+		})
+	}
+}
+
+func TestUserPasswordResourceUpgradeState(t *testing.T) {
+	ctx := context.Background()
+	s := testUserPasswordResourceSchema(t)
+	upgrader := NewUserPasswordResource().(fwresource.ResourceWithUpgradeState).UpgradeState(ctx)[0] //nolint:forcetypeassert // asserted by the resource
+
+	prior := testObject(t, upgrader.PriorSchema.Type().TerraformType(ctx), map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, "tf-user"),
+		"username":     tftypes.NewValue(tftypes.String, "tf-user"),
+		"old_password": tftypes.NewValue(tftypes.String, "initial-password"),
+		"new_password": tftypes.NewValue(tftypes.String, "first-password"),
+		"reset":        tftypes.NewValue(tftypes.Bool, false),
+		"last_updated": tftypes.NewValue(tftypes.String, "yesterday"),
+	})
+
+	resp := &fwresource.UpgradeStateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}}
+	upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{
+		State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: prior},
+	}, resp)
+
+	testCheckDiagnostics(t, resp.Diagnostics, "")
+	testCheckState(t, resp.State, map[string]string{
+		"id":           "tf-user",
+		"username":     "tf-user",
+		"last_updated": "yesterday",
+	})
+
+	for _, name := range []string{"old_password", "new_password"} {
+		var password types.String
+		resp.State.GetAttribute(ctx, path.Root(name), &password)
+		if !password.IsNull() {
+			t.Errorf("expected %s to be dropped, got %s", name, password)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// connectionAttributes are the provider attributes connection problems are
+// reported against. They depend on how the provider is configured, e.g. a
+// wrong authentication server path is on auth_endpoint when it is set
+// explicitly and on host otherwise.
+type connectionAttributes struct {
+	AuthEndpoint path.Path
+	APIEndpoint  path.Path
+	Credentials  path.Path
+	CACert       path.Path
+}
+
+// validateConnection checks that both servers can be reached and accept the
+// credentials, so that configuration mistakes are reported once against the
+// provider block instead of as errors of every resource.
+func validateConnection(ctx context.Context, client *apiClient, attributes connectionAttributes) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ctx = client.redactor.Context(ctx)
+
+	// Decoding the status catches endpoints answering with something other
+	// than the authentication server, such as the web UI
+	var status struct{}
+	if err := doJSON(ctx, client.httpClient, http.MethodGet, client.authEndpoint+"/status", "", nil, &status); err != nil {
+		diags.Append(connectionDiagnostic(attributes, attributes.AuthEndpoint, "authentication server", client.authEndpoint, err))
+		return diags
+	}
+
+	token, err := client.tokens.Token(ctx)
+	if err != nil {
+		diags.Append(credentialsDiagnostic(attributes, client.authEndpoint, client.redactor.Error(err)))
+		return diags
+	}
+
+	// API tokens are JWTs as well, anything else is left for the server to judge
+	if claims, err := parseTokenClaims(token); err == nil {
+		if err := client.auth(ctx, http.MethodGet, "/get_user/"+claims.UserID, nil, nil); err != nil {
+			diags.Append(credentialsDiagnostic(attributes, client.authEndpoint, err))
+			return diags
+		}
+	}
+
+	var graphqlErr *graphqlError
+	if err := client.graphql(ctx, `query { __typename }`, nil, nil); err != nil && !errors.As(err, &graphqlErr) {
+		diags.Append(connectionDiagnostic(attributes, attributes.APIEndpoint, "GraphQL server", client.apiEndpoint, err))
+		return diags
+	}
+
+	return diags
+}
+
+// credentialsDiagnostic reports a failure to authenticate, which is on the
+// credentials when the server rejected them.
+func credentialsDiagnostic(attributes connectionAttributes, endpoint string, err error) diag.Diagnostic {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && (statusErr.StatusCode == http.StatusUnauthorized || statusErr.StatusCode == http.StatusForbidden) {
+		return diag.NewAttributeErrorDiagnostic(
+			attributes.Credentials,
+			"Invalid Litmus Chaos Credentials",
+			fmt.Sprintf("The Litmus Chaos authentication server rejected the credentials with status %d. "+
+				"Check that the token has not expired or been revoked, or that the username and password are correct.\n\n"+
+				"Error: %s", statusErr.StatusCode, err),
+		)
+	}
+
+	return connectionDiagnostic(attributes, attributes.AuthEndpoint, "authentication server", endpoint, err)
+}
+
+// connectionDiagnostic turns the error of a request to endpoint into a
+// diagnostic explaining the most likely cause.
+func connectionDiagnostic(attributes connectionAttributes, attribute path.Path, server string, endpoint string, err error) diag.Diagnostic {
+	var (
+		dnsErr           *net.DNSError
+		unknownAuthority x509.UnknownAuthorityError
+		certInvalid      x509.CertificateInvalidError
+		hostnameErr      x509.HostnameError
+		recordHeaderErr  tls.RecordHeaderError
+		opErr            *net.OpError
+		statusErr        *statusError
+	)
+
+	switch {
+	case errors.As(err, &dnsErr):
+		return diag.NewAttributeErrorDiagnostic(
+			attribute,
+			"Unable to Resolve Litmus Chaos Host",
+			fmt.Sprintf("The host %s of the Litmus Chaos %s could not be resolved. Check the hostname for typos.\n\nError: %s", dnsErr.Name, server, err),
+		)
+	case errors.As(err, &unknownAuthority), errors.As(err, &certInvalid):
+		return diag.NewAttributeErrorDiagnostic(
+			attributes.CACert,
+			"Untrusted Litmus Chaos Certificate",
+			fmt.Sprintf("The certificate of the Litmus Chaos %s at %s is not trusted. "+
+				"Provide the CA that issued it with ca_cert_pem or ca_cert_file.\n\nError: %s", server, endpoint, err),
+		)
+	case errors.As(err, &hostnameErr):
+		return diag.NewAttributeErrorDiagnostic(
+			attribute,
+			"Litmus Chaos Certificate Hostname Mismatch",
+			fmt.Sprintf("The certificate of the Litmus Chaos %s is not valid for %s. "+
+				"Use a hostname the certificate was issued for.\n\nError: %s", server, endpoint, err),
+		)
+	// net/http replaces the TLS error by a plain one for this common mistake
+	case errors.As(err, &recordHeaderErr), strings.Contains(err.Error(), "server gave HTTP response to HTTPS client"):
+		return diag.NewAttributeErrorDiagnostic(
+			attribute,
+			"Litmus Chaos Server Does Not Use TLS",
+			fmt.Sprintf("The Litmus Chaos %s at %s did not answer with TLS. "+
+				"Use an http:// URL if it is not served over HTTPS.\n\nError: %s", server, endpoint, err),
+		)
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound:
+		return diag.NewAttributeErrorDiagnostic(
+			attribute,
+			"Litmus Chaos Endpoint Not Found",
+			fmt.Sprintf("The Litmus Chaos %s was not found at %s. Check the path of the URL.\n\nError: %s", server, endpoint, err),
+		)
+	case errors.As(err, &statusErr) && (statusErr.StatusCode == http.StatusUnauthorized || statusErr.StatusCode == http.StatusForbidden):
+		return diag.NewAttributeErrorDiagnostic(
+			attributes.Credentials,
+			"Invalid Litmus Chaos Credentials",
+			fmt.Sprintf("The Litmus Chaos %s rejected the credentials with status %d.\n\nError: %s", server, statusErr.StatusCode, err),
+		)
+	case errors.As(err, &opErr):
+		return diag.NewAttributeErrorDiagnostic(
+			attribute,
+			"Unable to Connect to Litmus Chaos",
+			fmt.Sprintf("The Litmus Chaos %s at %s could not be reached. Check the URL and that the server is running.\n\nError: %s", server, endpoint, err),
+		)
+	}
+
+	return diag.NewAttributeErrorDiagnostic(
+		attribute,
+		"Unexpected Litmus Chaos Server Response",
+		fmt.Sprintf("The Litmus Chaos %s at %s did not answer as expected. Check that the URL points to it.\n\nError: %s", server, endpoint, err),
+	)
+}
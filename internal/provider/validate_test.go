@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestValidateConnection(t *testing.T) {
+	validToken := testToken(`{"uid":"b1c2","username":"admin"}`)
+
+	attributes := connectionAttributes{
+		AuthEndpoint: path.Root("auth_endpoint"),
+		APIEndpoint:  path.Root("api_endpoint"),
+		Credentials:  path.Root("token"),
+		CACert:       path.Root("ca_cert_pem"),
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/auth/status":
+			fmt.Fprint(w, `{"status":"up"}`)
+		case r.Header.Get("Authorization") != "Bearer "+validToken:
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/auth/get_user/b1c2":
+			fmt.Fprint(w, `{"userID":"b1c2"}`)
+		case r.URL.Path == "/api/query":
+			fmt.Fprint(w, `{"data":{"__typename":"Query"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	tlsServer := httptest.NewTLSServer(handler)
+	defer tlsServer.Close()
+
+	testCases := map[string]struct {
+		authEndpoint      string
+		apiEndpoint       string
+		token             string
+		expectedSummary   string
+		expectedAttribute path.Path
+	}{
+		"valid": {
+			authEndpoint: server.URL + "/auth",
+			apiEndpoint:  server.URL + "/api/query",
+			token:        validToken,
+		},
+		"unknown host": {
+			authEndpoint:      "http://litmus.invalid/auth",
+			apiEndpoint:       server.URL + "/api/query",
+			token:             validToken,
+			expectedSummary:   "Unable to Resolve Litmus Chaos Host",
+			expectedAttribute: path.Root("auth_endpoint"),
+		},
+		"untrusted certificate": {
+			authEndpoint:      tlsServer.URL + "/auth",
+			apiEndpoint:       tlsServer.URL + "/api/query",
+			token:             validToken,
+			expectedSummary:   "Untrusted Litmus Chaos Certificate",
+			expectedAttribute: path.Root("ca_cert_pem"),
+		},
+		"plain http server": {
+			authEndpoint:      strings.Replace(server.URL, "http://", "https://", 1) + "/auth",
+			apiEndpoint:       server.URL + "/api/query",
+			token:             validToken,
+			expectedSummary:   "Litmus Chaos Server Does Not Use TLS",
+			expectedAttribute: path.Root("auth_endpoint"),
+		},
+		"rejected token": {
+			authEndpoint:      server.URL + "/auth",
+			apiEndpoint:       server.URL + "/api/query",
+			token:             testToken(`{"uid":"b1c2","username":"revoked"}`),
+			expectedSummary:   "Invalid Litmus Chaos Credentials",
+			expectedAttribute: path.Root("token"),
+		},
+		"wrong api path": {
+			authEndpoint:      server.URL + "/auth",
+			apiEndpoint:       server.URL + "/query",
+			token:             validToken,
+			expectedSummary:   "Litmus Chaos Endpoint Not Found",
+			expectedAttribute: path.Root("api_endpoint"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diags := validateConnection(context.Background(), &apiClient{
+				authEndpoint: testCase.authEndpoint,
+				apiEndpoint:  testCase.apiEndpoint,
+				tokens:       staticTokenSource(testCase.token),
+				httpClient:   &http.Client{},
+			}, attributes)
+
+			if testCase.expectedSummary == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+
+			if len(diags) != 1 {
+				t.Fatalf("expected a single diagnostic, got %v", diags)
+			}
+
+			if actual := diags[0].Summary(); actual != testCase.expectedSummary {
+				t.Errorf("expected summary %q, got %q: %s", testCase.expectedSummary, actual, diags[0].Detail())
+			}
+
+			withPath, ok := diags[0].(interface{ Path() path.Path })
+			if !ok || !withPath.Path().Equal(testCase.expectedAttribute) {
+				t.Errorf("expected diagnostic on %s, got %v", testCase.expectedAttribute, diags[0])
+			}
+		})
+	}
+}
@@ -6,7 +6,9 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"os"
 
 	"github.com/williamokano/terraform-provider-litmus-chaos/internal/provider"
 
@@ -33,6 +35,11 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		export(os.Args[2:])
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
@@ -49,3 +56,31 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// export writes the configuration of existing control plane objects, see
+// provider.Export. It connects with the same LITMUS_CHAOS_* environment
+// variables as the provider.
+func export(args []string) {
+	var options provider.ExportOptions
+
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s export [options]\n\n", os.Args[0])
+		fmt.Fprintln(flags.Output(), "Writes Terraform resources and import blocks for existing Litmus Chaos objects,")
+		fmt.Fprintln(flags.Output(), "connecting with the LITMUS_CHAOS_* environment variables the provider uses.")
+		fmt.Fprintln(flags.Output())
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&options.ProjectID, "project-id", "", "project to export, defaults to LITMUS_CHAOS_PROJECT_ID or every project when unset")
+	flags.StringVar(&options.Dir, "out", ".", "directory to write the .tf files to")
+	_ = flags.Parse(args)
+
+	files, err := provider.Export(context.Background(), version, options)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	for _, file := range files {
+		fmt.Println("Wrote", file)
+	}
+}